@@ -34,8 +34,10 @@ type UserSession struct {
 }
 
 type UserAccess struct {
-	AccessToken string    `json:"accessToken"`
-	ExpiresAt   time.Time `json:"expiresAt"`
+	AccessToken      string    `json:"accessToken"`
+	ExpiresAt        time.Time `json:"expiresAt"`
+	RefreshToken     string    `json:"refreshToken"`
+	RefreshExpiresAt time.Time `json:"refreshExpiresAt"`
 }
 
 func GenerateToken(userID uuid.UUID, email string, duration time.Duration, tokenType TokenType) (string, error) {
@@ -44,6 +46,7 @@ func GenerateToken(userID uuid.UUID, email string, duration time.Duration, token
 		"iat":        time.Now().UTC().Unix(),
 		"exp":        exp.UTC().Unix(),
 		"sub":        userID.String(),
+		"jti":        uuid.NewString(),
 		"token_type": tokenType,
 		"email":      email,
 	})
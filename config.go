@@ -1,29 +1,84 @@
 package main
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/primekobie/hazel/mail"
 )
 
+// defaultMailTimeout bounds a single request to the mail provider.
+const defaultMailTimeout = 10 * time.Second
+
 type Config struct {
+	Dev         bool
 	MailConfig  *mail.Config
 	PostgresURL string
 	ServerAddress string
+	StorageDir  string
 }
 
 func loadConfig() *Config {
+	dev := os.Getenv("ENV") == "dev"
+
+	mailTimeout := defaultMailTimeout
+	if value := os.Getenv("MAIL_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			panic(fmt.Sprintf("MAIL_TIMEOUT must be a positive duration such as 10s, got %q", value))
+		}
+		mailTimeout = timeout
+	}
+
+	smtpCfg := mail.SMTPConfig{
+		Host:     os.Getenv("SMTP_HOST"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		StartTLS: true,
+	}
+	if value := os.Getenv("SMTP_PORT"); value != "" {
+		port, err := strconv.Atoi(value)
+		if err != nil || port <= 0 || port > 65535 {
+			panic(fmt.Sprintf("SMTP_PORT must be a port number, got %q", value))
+		}
+		smtpCfg.Port = port
+	}
+	if value := os.Getenv("SMTP_STARTTLS"); value != "" {
+		startTLS, err := strconv.ParseBool(value)
+		if err != nil {
+			panic(fmt.Sprintf("SMTP_STARTTLS must be true or false, got %q", value))
+		}
+		smtpCfg.StartTLS = startTLS
+	}
 
 	mailCfg := &mail.Config{
+		Transport:   os.Getenv("MAIL_TRANSPORT"),
 		Host:        os.Getenv("MAIL_HOST"),
 		Token:       os.Getenv("MAIL_TOKEN"),
+		SMTP:        smtpCfg,
+		SinkDir:     os.Getenv("MAIL_SINK_DIR"),
+		Timeout:     mailTimeout,
 		SenderEmail: os.Getenv("SENDER_EMAIL"),
 		SenderName:  os.Getenv("SENDER_NAME"),
 	}
 
+	// the sink drops every email, which is only useful while developing
+	if mailCfg.Transport == mail.TransportSink && !dev {
+		panic("MAIL_TRANSPORT=sink is only allowed when ENV=dev")
+	}
+
+	storageDir := os.Getenv("STORAGE_DIR")
+	if storageDir == "" {
+		storageDir = "uploads"
+	}
+
 	return &Config{
+		Dev:         dev,
 		MailConfig:  mailCfg,
 		PostgresURL: os.Getenv("DB_URL"),
 		ServerAddress: os.Getenv("PORT"),
+		StorageDir:  storageDir,
 	}
 }
@@ -0,0 +1,173 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultReplaySize is the number of events kept per workspace for clients
+// resuming a stream with Last-Event-ID.
+const DefaultReplaySize = 256
+
+// subscriberBuffer is the number of events queued for a subscriber before it
+// is considered too slow and dropped.
+const subscriberBuffer = 64
+
+// Event is a change in a workspace delivered to its subscribers. Ids are
+// assigned by the bus and increase monotonically across all workspaces.
+type Event struct {
+	Id          uint64    `json:"id"`
+	WorkspaceId uuid.UUID `json:"workspaceId"`
+	Type        string    `json:"type"`
+	Data        any       `json:"data"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// Subscription receives the events of a single workspace. Events is closed
+// when the subscription is cancelled or the subscriber falls too far behind.
+type Subscription struct {
+	Events <-chan Event
+
+	events      chan Event
+	workspaceId uuid.UUID
+	bus         *Bus
+}
+
+// Close cancels the subscription.
+func (s *Subscription) Close() {
+	s.bus.unsubscribe(s)
+}
+
+type topic struct {
+	replay      []Event
+	evicted     uint64 // id of the newest event dropped from replay
+	subscribers map[*Subscription]struct{}
+}
+
+// Bus is an in-process publish/subscribe hub for workspace events.
+type Bus struct {
+	mu         sync.Mutex
+	lastId     uint64
+	replaySize int
+	topics     map[uuid.UUID]*topic
+}
+
+func NewBus(replaySize int) *Bus {
+	if replaySize < 1 {
+		replaySize = DefaultReplaySize
+	}
+
+	return &Bus{
+		replaySize: replaySize,
+		topics:     map[uuid.UUID]*topic{},
+	}
+}
+
+func (b *Bus) topic(workspaceId uuid.UUID) *topic {
+	t, ok := b.topics[workspaceId]
+	if !ok {
+		t = &topic{subscribers: map[*Subscription]struct{}{}}
+		b.topics[workspaceId] = t
+	}
+	return t
+}
+
+// Publish delivers an event to the current subscribers of the workspace and
+// keeps it for replay. Publishing never blocks on slow subscribers.
+func (b *Bus) Publish(workspaceId uuid.UUID, eventType string, data any) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastId++
+	event := Event{
+		Id:          b.lastId,
+		WorkspaceId: workspaceId,
+		Type:        eventType,
+		Data:        data,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	t := b.topic(workspaceId)
+	t.replay = append(t.replay, event)
+	if overflow := len(t.replay) - b.replaySize; overflow > 0 {
+		t.evicted = t.replay[overflow-1].Id
+		t.replay = append(t.replay[:0:0], t.replay[overflow:]...)
+	}
+
+	for sub := range t.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			b.remove(t, sub)
+		}
+	}
+
+	return event
+}
+
+// Subscribe starts receiving the events of a workspace. When lastId is not
+// zero the buffered events published after it are returned for replay; ok
+// is false if some of those events are no longer buffered.
+func (b *Bus) Subscribe(workspaceId uuid.UUID, lastId uint64) (sub *Subscription, replay []Event, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	events := make(chan Event, subscriberBuffer)
+	sub = &Subscription{
+		Events:      events,
+		events:      events,
+		workspaceId: workspaceId,
+		bus:         b,
+	}
+
+	t := b.topic(workspaceId)
+	t.subscribers[sub] = struct{}{}
+
+	if lastId == 0 {
+		return sub, nil, true
+	}
+
+	for i, event := range t.replay {
+		if event.Id > lastId {
+			replay = append(replay, t.replay[i:]...)
+			break
+		}
+	}
+
+	// ids from before a restart are ahead of the bus and can't be resumed either
+	return sub, replay, lastId >= t.evicted && lastId <= b.lastId
+}
+
+func (b *Bus) unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if t, ok := b.topics[sub.workspaceId]; ok {
+		b.remove(t, sub)
+	}
+}
+
+// remove detaches sub from t and closes its channel. It must be called with
+// b.mu held.
+func (b *Bus) remove(t *topic, sub *Subscription) {
+	if _, ok := t.subscribers[sub]; !ok {
+		return
+	}
+	delete(t.subscribers, sub)
+	close(sub.events)
+}
+
+// Close ends all subscriptions, letting open streams finish during shutdown.
+// Events published afterwards are still buffered for replay.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, t := range b.topics {
+		for sub := range t.subscribers {
+			b.remove(t, sub)
+		}
+	}
+}
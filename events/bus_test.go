@@ -0,0 +1,91 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBus_PublishSubscribe(t *testing.T) {
+	bus := NewBus(10)
+	workspaceId := uuid.New()
+
+	sub, replay, ok := bus.Subscribe(workspaceId, 0)
+	defer sub.Close()
+	assert.True(t, ok)
+	assert.Empty(t, replay)
+
+	bus.Publish(uuid.New(), "task.created", "other workspace")
+	published := bus.Publish(workspaceId, "task.created", "data")
+
+	event := <-sub.Events
+	assert.Equal(t, published, event)
+	assert.Equal(t, uint64(2), event.Id)
+	assert.Len(t, sub.Events, 0)
+}
+
+func TestBus_Replay(t *testing.T) {
+	bus := NewBus(3)
+	workspaceId := uuid.New()
+
+	for range 5 {
+		bus.Publish(workspaceId, "task.updated", nil)
+	}
+
+	tests := []struct {
+		name   string
+		lastId uint64
+		ids    []uint64
+		ok     bool
+	}{
+		{name: "up to date", lastId: 5, ids: nil, ok: true},
+		{name: "missed buffered events", lastId: 3, ids: []uint64{4, 5}, ok: true},
+		{name: "oldest missed event evicted", lastId: 1, ids: []uint64{3, 4, 5}, ok: false},
+		{name: "id from before restart", lastId: 99, ids: nil, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, replay, ok := bus.Subscribe(workspaceId, tt.lastId)
+			defer sub.Close()
+
+			var ids []uint64
+			for _, event := range replay {
+				ids = append(ids, event.Id)
+			}
+			assert.Equal(t, tt.ids, ids)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestBus_DropsSlowSubscribers(t *testing.T) {
+	bus := NewBus(1)
+	workspaceId := uuid.New()
+
+	sub, _, _ := bus.Subscribe(workspaceId, 0)
+	for range subscriberBuffer + 1 {
+		bus.Publish(workspaceId, "task.updated", nil)
+	}
+
+	received := 0
+	for range sub.Events {
+		received++
+	}
+	assert.Equal(t, subscriberBuffer, received)
+
+	// closing an already dropped subscription is a no-op
+	require.NotPanics(t, sub.Close)
+}
+
+func TestBus_Close(t *testing.T) {
+	bus := NewBus(1)
+	sub, _, _ := bus.Subscribe(uuid.New(), 0)
+
+	bus.Close()
+
+	_, open := <-sub.Events
+	assert.False(t, open)
+}
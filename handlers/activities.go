@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetWorkspaceActivity godoc
+//	@Summary		Get workspace activity
+//	@Description	Get a page of the workspace's activity log, newest first by default
+//	@Tags			workspaces
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id			path		string	true	"Workspace ID"
+//	@Param			actor		query		string	false	"ID of the user who made the change"
+//	@Param			entityType	query		string	false	"Type of the changed entity"	Enums(workspace, project, task, comment, member)
+//	@Param			entityId	query		string	false	"ID of the changed entity"
+//	@Param			order		query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param			limit		query		int		false	"Page size"
+//	@Param			cursor		query		string	false	"Cursor of the next page"
+//	@Success		200			{object}	models.Page[models.Activity]
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/workspaces/{id}/activity [get]
+func (h *Handler) GetWorkspaceActivity(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	opts, err := getListOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// the log is read newest first unless asked otherwise
+	if c.Query("order") == "" {
+		opts.Desc = true
+	}
+
+	filter := models.ActivityFilter{
+		EntityType:  models.Resource(c.Query("entityType")),
+		ListOptions: opts,
+	}
+
+	if actor := c.Query("actor"); actor != "" {
+		filter.Actor, err = uuid.Parse(actor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "actor must be a valid user id"})
+			return
+		}
+	}
+
+	if entityId := c.Query("entityId"); entityId != "" {
+		filter.EntityId, err = uuid.Parse(entityId)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "entityId must be a valid id"})
+			return
+		}
+	}
+
+	activities, err := h.workspaces.GetActivities(c.Request.Context(), id, filter)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, activities)
+}
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/primekobie/hazel/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// uploadOverhead is the room left in a request body for the multipart
+// framing around the uploaded file.
+const uploadOverhead = 1 << 20
+
+// getUploadedFile reads the "file" part of a multipart request, rejecting
+// bodies much larger than limit before they are buffered. On failure it
+// writes the response itself.
+func getUploadedFile(c *gin.Context, limit int64) (*multipart.FileHeader, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit+uploadOverhead)
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"message": fileTooLargeMessage(limit)})
+			return nil, false
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"message": "a file must be uploaded in the 'file' form field"})
+		return nil, false
+	}
+
+	if file.Size > limit {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"message": fileTooLargeMessage(limit)})
+		return nil, false
+	}
+
+	return file, true
+}
+
+func fileTooLargeMessage(limit int64) string {
+	return fmt.Sprintf("files cannot be larger than %d MB", limit>>20)
+}
+
+// uploadError writes the response for an error returned while storing an
+// uploaded file.
+func uploadError(c *gin.Context, err error, limit int64) {
+	switch {
+	case errors.Is(err, models.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+	case errors.Is(err, services.ErrFileTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"message": fileTooLargeMessage(limit)})
+	case errors.Is(err, services.ErrFileType):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"message": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+	}
+}
+
+// AddTaskAttachment godoc
+//	@Summary		Attach file to task
+//	@Description	Upload a file of at most 25 MB to a task. The content type is detected from the file and must be an image,
+//	@Description	PDF, archive, plain text, JSON, audio or video file.
+//	@Tags			attachments
+//	@Security		BearerAuth
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		string	true	"Task ID"
+//	@Param			file	formData	file	true	"File to attach"
+//	@Success		201		{object}	models.Attachment
+//	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		413		{object}	map[string]string
+//	@Failure		415		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/tasks/{id}/attachments [post]
+func (h *Handler) AddTaskAttachment(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	header, ok := getUploadedFile(c, services.MaxAttachmentSize)
+	if !ok {
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		slog.Error("failed to open uploaded file", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+	defer file.Close()
+
+	userId := uuid.MustParse(c.GetString("user_id"))
+	attachment, err := h.workspaces.AddAttachment(c.Request.Context(), id, userId, header.Filename, file)
+	if err != nil {
+		uploadError(c, err, services.MaxAttachmentSize)
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// GetTaskAttachments godoc
+//	@Summary		Get task attachments
+//	@Description	Get the files attached to a task, oldest first
+//	@Tags			attachments
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Task ID"
+//	@Success		200	{array}		models.Attachment
+//	@Failure		400	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/tasks/{id}/attachments [get]
+func (h *Handler) GetTaskAttachments(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	attachments, err := h.workspaces.GetTaskAttachments(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attachments)
+}
+
+// DownloadTaskAttachment godoc
+//	@Summary		Download task attachment
+//	@Description	Stream the contents of a file attached to a task
+//	@Tags			attachments
+//	@Security		BearerAuth
+//	@Produce		octet-stream
+//	@Param			id				path		string	true	"Task ID"
+//	@Param			attachment_id	path		string	true	"Attachment ID"
+//	@Success		200				{file}		file
+//	@Failure		400				{object}	map[string]string
+//	@Failure		403				{object}	map[string]string
+//	@Failure		404				{object}	map[string]string
+//	@Failure		500				{object}	map[string]string
+//	@Router			/tasks/{id}/attachments/{attachment_id} [get]
+func (h *Handler) DownloadTaskAttachment(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	attachmentId, err := getUUIDparam(c, "attachment_id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	attachment, file, err := h.workspaces.OpenAttachment(c.Request.Context(), id, attachmentId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, file, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Name}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// DeleteTaskAttachment godoc
+//	@Summary		Delete task attachment
+//	@Description	Remove a file from a task. Files can be removed by whoever uploaded them or by a workspace admin.
+//	@Tags			attachments
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id				path		string	true	"Task ID"
+//	@Param			attachment_id	path		string	true	"Attachment ID"
+//	@Success		200				{object}	map[string]string
+//	@Failure		400				{object}	map[string]string
+//	@Failure		403				{object}	map[string]string
+//	@Failure		404				{object}	map[string]string
+//	@Failure		500				{object}	map[string]string
+//	@Router			/tasks/{id}/attachments/{attachment_id} [delete]
+func (h *Handler) DeleteTaskAttachment(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	attachmentId, err := getUUIDparam(c, "attachment_id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	userId := uuid.MustParse(c.GetString("user_id"))
+	role := c.MustGet("workspace_role").(models.Role)
+
+	err = h.workspaces.DeleteAttachment(c.Request.Context(), id, attachmentId, userId, role)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		} else if errors.Is(err, services.ErrPermissionDenied) {
+			c.JSON(http.StatusForbidden, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "attachment successfully deleted"})
+}
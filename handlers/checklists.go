@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateChecklistItem godoc
+//	@Summary		Add checklist item
+//	@Description	Add an item to the end of a task's checklist
+//	@Tags			checklists
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Task ID"
+//	@Param			item	body		object	true	"Item title"
+//	@Success		201		{object}	models.ChecklistItem
+//	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/tasks/{id}/checklist [post]
+func (h *Handler) CreateChecklistItem(c *gin.Context) {
+	taskId, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		Title string `json:"title" binding:"required"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	item, err := h.workspaces.AddChecklistItem(c.Request.Context(), taskId, input.Title)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+// GetChecklist godoc
+//	@Summary		Get checklist
+//	@Description	Get the checklist items of a task in order
+//	@Tags			checklists
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Task ID"
+//	@Success		200	{array}		models.ChecklistItem
+//	@Failure		400	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/tasks/{id}/checklist [get]
+func (h *Handler) GetChecklist(c *gin.Context) {
+	taskId, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	items, err := h.workspaces.GetChecklist(c.Request.Context(), taskId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// UpdateChecklistItem godoc
+//	@Summary		Update checklist item
+//	@Description	Rename, check off or move a checklist item
+//	@Tags			checklists
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Task ID"
+//	@Param			item_id	path		string	true	"Checklist item ID"
+//	@Param			item	body		object	true	"Any of title, done and position"
+//	@Success		200		{object}	models.ChecklistItem
+//	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/tasks/{id}/checklist/{item_id} [patch]
+func (h *Handler) UpdateChecklistItem(c *gin.Context) {
+	taskId, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	itemId, err := getUUIDparam(c, "item_id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		Title    *string `json:"title" binding:"omitnil,min=1"`
+		Done     *bool   `json:"done"`
+		Position *int    `json:"position" binding:"omitnil,min=1"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	item, err := h.workspaces.UpdateChecklistItem(c.Request.Context(), taskId, itemId, input.Title, input.Done, input.Position)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// DeleteChecklistItem godoc
+//	@Summary		Delete checklist item
+//	@Description	Remove an item from a task's checklist
+//	@Tags			checklists
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id		path		string	true	"Task ID"
+//	@Param			item_id	path		string	true	"Checklist item ID"
+//	@Success		200		{object}	map[string]string
+//	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/tasks/{id}/checklist/{item_id} [delete]
+func (h *Handler) DeleteChecklistItem(c *gin.Context) {
+	taskId, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	itemId, err := getUUIDparam(c, "item_id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	err = h.workspaces.DeleteChecklistItem(c.Request.Context(), taskId, itemId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "checklist item successfully deleted"})
+}
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/primekobie/hazel/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreateComment godoc
+//	@Summary		Create comment
+//	@Description	Comment on a task or reply to a top-level comment. Workspace members can be mentioned with @handle
+//	@Tags			comments
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Task ID"
+//	@Param			comment	body		object	true	"Comment body and optional parentId"
+//	@Success		201		{object}	models.Comment
+//	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		422		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/tasks/{id}/comments [post]
+func (h *Handler) CreateComment(c *gin.Context) {
+	taskId, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		Body     string    `json:"body" binding:"required"`
+		ParentId uuid.UUID `json:"parentId"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	authorId := uuid.MustParse(c.GetString("user_id"))
+
+	comment, err := h.workspaces.AddComment(c.Request.Context(), taskId, authorId, input.ParentId, input.Body)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		} else if errors.Is(err, services.ErrNestedReply) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// GetTaskComments godoc
+//	@Summary		Get task comments
+//	@Description	Get the comments of a task with replies nested under their parent
+//	@Tags			comments
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Task ID"
+//	@Success		200	{array}		models.Comment
+//	@Failure		400	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/tasks/{id}/comments [get]
+func (h *Handler) GetTaskComments(c *gin.Context) {
+	taskId, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	comments, err := h.workspaces.GetTaskComments(c.Request.Context(), taskId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+// UpdateComment godoc
+//	@Summary		Edit comment
+//	@Description	Replace the body of a comment. Only the author can edit a comment
+//	@Tags			comments
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Task ID"
+//	@Param			comment_id	path		string	true	"Comment ID"
+//	@Param			comment		body		object	true	"New comment body"
+//	@Success		200			{object}	models.Comment
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/tasks/{id}/comments/{comment_id} [patch]
+func (h *Handler) UpdateComment(c *gin.Context) {
+	taskId, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	commentId, err := getUUIDparam(c, "comment_id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		Body string `json:"body" binding:"required"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	userId := uuid.MustParse(c.GetString("user_id"))
+
+	comment, err := h.workspaces.EditComment(c.Request.Context(), taskId, commentId, userId, input.Body)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		} else if errors.Is(err, services.ErrPermissionDenied) {
+			c.JSON(http.StatusForbidden, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+// DeleteComment godoc
+//	@Summary		Delete comment
+//	@Description	Delete a comment and its replies. Allowed for the author and workspace admins
+//	@Tags			comments
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id			path		string	true	"Task ID"
+//	@Param			comment_id	path		string	true	"Comment ID"
+//	@Success		200			{object}	map[string]string
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/tasks/{id}/comments/{comment_id} [delete]
+func (h *Handler) DeleteComment(c *gin.Context) {
+	taskId, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	commentId, err := getUUIDparam(c, "comment_id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	userId := uuid.MustParse(c.GetString("user_id"))
+	role := c.MustGet("workspace_role").(models.Role)
+
+	err = h.workspaces.DeleteComment(c.Request.Context(), taskId, commentId, userId, role)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		} else if errors.Is(err, services.ErrPermissionDenied) {
+			c.JSON(http.StatusForbidden, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "comment successfully deleted"})
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/primekobie/hazel/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetTaskDependencies godoc
+//	@Summary		Get task dependencies
+//	@Description	Get the tasks blocking a task and the tasks it blocks
+//	@Tags			tasks
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Task ID"
+//	@Success		200	{object}	models.TaskDependencies
+//	@Failure		400	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/tasks/{id}/dependencies [get]
+func (h *Handler) GetTaskDependencies(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	deps, err := h.workspaces.GetDependencies(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, deps)
+}
+
+// AddTaskDependency godoc
+//	@Summary		Add task dependency
+//	@Description	Mark a task as blocked by another task in the same workspace. Dependencies that would form a cycle are rejected
+//	@Tags			tasks
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Task ID"
+//	@Param			dependency	body		object	true	"ID of the blocking task"
+//	@Success		201			{object}	map[string]string
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		409			{object}	map[string]string
+//	@Failure		422			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/tasks/{id}/dependencies [post]
+func (h *Handler) AddTaskDependency(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		BlockerId uuid.UUID `json:"blockerId" binding:"required"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	err = h.workspaces.AddDependency(c.Request.Context(), id, input.BlockerId)
+	if err != nil {
+		if errors.Is(err, services.ErrDuplicateEntry) {
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
+		} else if errors.Is(err, services.ErrInvalidBlocker) || errors.Is(err, services.ErrDependencyCycle) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "task dependency successfully added"})
+}
+
+// RemoveTaskDependency godoc
+//	@Summary		Remove task dependency
+//	@Description	Stop a task from being blocked by another task
+//	@Tags			tasks
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id			path		string	true	"Task ID"
+//	@Param			blocker_id	path		string	true	"Blocking task ID"
+//	@Success		200			{object}	map[string]string
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/tasks/{id}/dependencies/{blocker_id} [delete]
+func (h *Handler) RemoveTaskDependency(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	blockerId, err := getUUIDparam(c, "blocker_id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	err = h.workspaces.RemoveDependency(c.Request.Context(), id, blockerId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "task dependency successfully removed"})
+}
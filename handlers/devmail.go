@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/primekobie/hazel/mail"
+	"github.com/gin-gonic/gin"
+)
+
+// DevMail serves the messages caught by a development mail sink. Its routes
+// are unauthenticated and must only be registered in development.
+type DevMail struct {
+	sink *mail.Sink
+}
+
+func NewDevMail(sink *mail.Sink) *DevMail {
+	return &DevMail{sink: sink}
+}
+
+// GetMessages lists the most recent messages caught by the sink, newest
+// first.
+func (d *DevMail) GetMessages(c *gin.Context) {
+	c.JSON(http.StatusOK, d.sink.Messages())
+}
+
+// GetMessage returns a single caught message.
+func (d *DevMail) GetMessage(c *gin.Context) {
+	msg, ok := d.message(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, msg)
+}
+
+// PreviewMessage renders the HTML part of a caught message so templates can
+// be checked in a browser.
+func (d *DevMail) PreviewMessage(c *gin.Context) {
+	msg, ok := d.message(c)
+	if !ok {
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(msg.HTML))
+}
+
+func (d *DevMail) message(c *gin.Context) (mail.SinkMessage, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid message id"})
+		return mail.SinkMessage{}, false
+	}
+
+	msg, ok := d.sink.Message(id)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"message": "message not found"})
+		return mail.SinkMessage{}, false
+	}
+	return msg, true
+}
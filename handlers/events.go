@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/primekobie/hazel/events"
+	"github.com/primekobie/hazel/models"
+	"github.com/primekobie/hazel/services"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// heartbeatInterval keeps idle event streams from being closed by proxies.
+const heartbeatInterval = 30 * time.Second
+
+// StreamWorkspaceEvents godoc
+//	@Summary		Stream workspace events
+//	@Description	Stream changes to the workspace, its members, projects, tasks and comments as Server-Sent Events.
+//	@Description	Each event is named after the change (e.g. task.updated) and carries the activity entry as data.
+//	@Description	Clients resuming with Last-Event-ID receive the events they missed; a "reset" event is sent instead when they are no longer buffered.
+//	@Tags			workspaces
+//	@Security		BearerAuth
+//	@Produce		text/event-stream
+//	@Param			id				path		string	true	"Workspace ID"
+//	@Param			Last-Event-ID	header		string	false	"ID of the last event received"
+//	@Success		200				{object}	models.Activity
+//	@Failure		400				{object}	map[string]string
+//	@Failure		403				{object}	map[string]string
+//	@Router			/workspaces/{id}/events [get]
+func (h *Handler) StreamWorkspaceEvents(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var lastId uint64
+	if header := c.GetHeader("Last-Event-ID"); header != "" {
+		lastId, err = strconv.ParseUint(header, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "invalid Last-Event-ID"})
+			return
+		}
+	}
+
+	userId := uuid.MustParse(c.GetString("user_id"))
+
+	sub, replay, ok := h.workspaces.SubscribeEvents(id, lastId)
+	defer sub.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	if !ok {
+		c.Render(-1, sse.Event{Event: "reset", Data: gin.H{"message": "missed events are no longer available"}})
+	}
+	for _, event := range replay {
+		renderEvent(c, event)
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, open := <-sub.Events:
+			if !open {
+				return false
+			}
+			renderEvent(c, event)
+			return !revokesAccess(event, userId)
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+func renderEvent(c *gin.Context, event events.Event) {
+	c.Render(-1, sse.Event{
+		Id:    strconv.FormatUint(event.Id, 10),
+		Event: event.Type,
+		Data:  event.Data,
+	})
+}
+
+// revokesAccess reports whether the event ends the user's access to the
+// workspace, in which case the stream is closed after delivering it.
+func revokesAccess(event events.Event, userId uuid.UUID) bool {
+	activity, ok := event.Data.(*models.Activity)
+	if !ok {
+		return false
+	}
+
+	switch event.Type {
+	case services.EventType(models.ResourceWorkspace, models.ActionDeleted):
+		return true
+	case services.EventType(models.ResourceMember, models.ActionRemoved):
+		return activity.EntityId == userId
+	}
+	return false
+}
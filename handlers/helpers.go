@@ -1,6 +1,13 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/primekobie/hazel/models"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -9,3 +16,140 @@ func getUUIDparam(c *gin.Context, key string) (uuid.UUID, error) {
 	idString := c.Param(key)
 	return uuid.Parse(idString)
 }
+
+// getQueryList returns the values of a query parameter that may be repeated
+// or given as a comma separated list.
+func getQueryList(c *gin.Context, key string) []string {
+	values := []string{}
+	for _, param := range c.QueryArray(key) {
+		for _, v := range strings.Split(param, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+// getQueryTime parses a query parameter given either as a date (YYYY-MM-DD)
+// or an RFC 3339 timestamp. endOfDay moves plain dates to the following
+// midnight so they can be used as an exclusive upper bound.
+func getQueryTime(c *gin.Context, key string, endOfDay bool) (time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(models.DateLayout, value); err == nil {
+		if endOfDay {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be a date (YYYY-MM-DD) or an RFC 3339 timestamp", key)
+	}
+	return t, nil
+}
+
+// getListOptions reads the limit, cursor, sort and order query parameters.
+func getListOptions(c *gin.Context) (models.ListOptions, error) {
+	opts := models.ListOptions{
+		Cursor: c.Query("cursor"),
+		Sort:   c.Query("sort"),
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 {
+			return opts, errors.New("limit must be a positive integer")
+		}
+		opts.Limit = n
+	}
+
+	switch c.Query("order") {
+	case "", "asc":
+	case "desc":
+		opts.Desc = true
+	default:
+		return opts, errors.New("order must be 'asc' or 'desc'")
+	}
+
+	return opts, nil
+}
+
+// getTaskFilter reads the task listing filters from the query string.
+func getTaskFilter(c *gin.Context) (models.TaskFilter, error) {
+	var filter models.TaskFilter
+	var err error
+
+	filter.ListOptions, err = getListOptions(c)
+	if err != nil {
+		return filter, err
+	}
+
+	for _, status := range getQueryList(c, "status") {
+		filter.Status = append(filter.Status, models.TaskStatus(status))
+	}
+
+	for _, priority := range getQueryList(c, "priority") {
+		filter.Priority = append(filter.Priority, models.TaskPriority(priority))
+	}
+
+	if assignee := c.Query("assignee"); assignee == "me" {
+		filter.Mine = true
+	} else if assignee != "" {
+		filter.Assignee, err = uuid.Parse(assignee)
+		if err != nil {
+			return filter, errors.New("assignee must be a valid user id or 'me'")
+		}
+	}
+
+	for _, label := range getQueryList(c, "labels") {
+		id, err := uuid.Parse(label)
+		if err != nil {
+			return filter, errors.New("labels must be valid label ids")
+		}
+		filter.Labels = append(filter.Labels, id)
+	}
+
+	if sprint := c.Query("sprint"); sprint == "backlog" {
+		filter.Backlog = true
+	} else if sprint != "" {
+		filter.Sprint, err = uuid.Parse(sprint)
+		if err != nil {
+			return filter, errors.New("sprint must be a valid sprint id or 'backlog'")
+		}
+	}
+
+	if milestone := c.Query("milestone"); milestone != "" {
+		filter.Milestone, err = uuid.Parse(milestone)
+		if err != nil {
+			return filter, errors.New("milestone must be a valid milestone id")
+		}
+	}
+
+	filter.DueFrom, err = getQueryTime(c, "dueFrom", false)
+	if err != nil {
+		return filter, err
+	}
+
+	filter.DueTo, err = getQueryTime(c, "dueTo", true)
+	if err != nil {
+		return filter, err
+	}
+
+	switch c.Query("overdue") {
+	case "", "false":
+	case "true":
+		filter.Overdue = true
+	default:
+		return filter, errors.New("overdue must be 'true' or 'false'")
+	}
+
+	filter.Search = c.Query("q")
+
+	return filter, nil
+}
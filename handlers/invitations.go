@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/primekobie/hazel/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreateInvitation godoc
+//	@Summary		Invite workspace member
+//	@Description	Invite an email address to join a workspace. The address doesn't need to belong to a registered user
+//	@Tags			invitations
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Workspace ID"
+//	@Param			invitation	body		object	true	"Email and role of the invitee"
+//	@Success		201			{object}	models.Invitation
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		409			{object}	map[string]string
+//	@Failure		422			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/workspaces/{id}/invitations [post]
+func (h *Handler) CreateInvitation(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		Email string      `json:"email" binding:"required,email"`
+		Role  models.Role `json:"role" binding:"required"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	inviterId := uuid.MustParse(c.GetString("user_id"))
+
+	invitation, err := h.workspaces.InviteMember(c.Request.Context(), id, inviterId, input.Email, input.Role)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRole) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error()})
+			return
+		} else if errors.Is(err, services.ErrAlreadyMember) {
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+// GetWorkspaceInvitations godoc
+//	@Summary		Get workspace invitations
+//	@Description	Get the invitations sent for a workspace, newest first
+//	@Tags			invitations
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Workspace ID"
+//	@Success		200	{array}		models.Invitation
+//	@Failure		400	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/workspaces/{id}/invitations [get]
+func (h *Handler) GetWorkspaceInvitations(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	invitations, err := h.workspaces.GetWorkspaceInvitations(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// DeleteInvitation godoc
+//	@Summary		Revoke invitation
+//	@Description	Revoke a pending workspace invitation
+//	@Tags			invitations
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id				path		string	true	"Workspace ID"
+//	@Param			invitation_id	path		string	true	"Invitation ID"
+//	@Success		200				{object}	map[string]string
+//	@Failure		400				{object}	map[string]string
+//	@Failure		403				{object}	map[string]string
+//	@Failure		404				{object}	map[string]string
+//	@Failure		409				{object}	map[string]string
+//	@Failure		500				{object}	map[string]string
+//	@Router			/workspaces/{id}/invitations/{invitation_id} [delete]
+func (h *Handler) DeleteInvitation(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	invitationId, err := getUUIDparam(c, "invitation_id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	err = h.workspaces.RevokeInvitation(c.Request.Context(), id, invitationId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		} else if errors.Is(err, services.ErrInvitationClosed) {
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "invitation successfully revoked"})
+}
+
+// GetUserInvitations godoc
+//	@Summary		Get my invitations
+//	@Description	Get the pending workspace invitations sent to the authenticated user's email
+//	@Tags			invitations
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Success		200	{array}		models.Invitation
+//	@Failure		500	{object}	map[string]string
+//	@Router			/users/me/invitations [get]
+func (h *Handler) GetUserInvitations(c *gin.Context) {
+	user, err := h.users.FetchUser(c.Request.Context(), uuid.MustParse(c.GetString("user_id")))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	invitations, err := h.workspaces.GetUserInvitations(c.Request.Context(), user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// AcceptInvitation godoc
+//	@Summary		Accept invitation
+//	@Description	Join the workspace of an invitation sent to the authenticated user's email
+//	@Tags			invitations
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Invitation ID"
+//	@Success		200	{object}	models.Invitation
+//	@Failure		400	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		409	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/invitations/{id}/accept [post]
+func (h *Handler) AcceptInvitation(c *gin.Context) {
+	h.respondToInvitation(c, true)
+}
+
+// DeclineInvitation godoc
+//	@Summary		Decline invitation
+//	@Description	Decline an invitation sent to the authenticated user's email
+//	@Tags			invitations
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Invitation ID"
+//	@Success		200	{object}	models.Invitation
+//	@Failure		400	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		409	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/invitations/{id}/decline [post]
+func (h *Handler) DeclineInvitation(c *gin.Context) {
+	h.respondToInvitation(c, false)
+}
+
+func (h *Handler) respondToInvitation(c *gin.Context, accept bool) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	user, err := h.users.FetchUser(c.Request.Context(), uuid.MustParse(c.GetString("user_id")))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	invitation, err := h.workspaces.RespondToInvitation(c.Request.Context(), id, user, accept)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		} else if errors.Is(err, services.ErrInvitationClosed) || errors.Is(err, services.ErrAlreadyMember) {
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/primekobie/hazel/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreateLabel godoc
+//	@Summary		Create label
+//	@Description	Create a label for categorizing the tasks of a workspace
+//	@Tags			labels
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Workspace ID"
+//	@Param			label	body		object	true	"Label name and hex color"
+//	@Success		201		{object}	models.Label
+//	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/workspaces/{id}/labels [post]
+func (h *Handler) CreateLabel(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		Name  string `json:"name" binding:"required,max=50"`
+		Color string `json:"color" binding:"required,hexcolor"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	label, err := h.workspaces.CreateLabel(c.Request.Context(), id, input.Name, input.Color)
+	if err != nil {
+		if errors.Is(err, services.ErrDuplicateEntry) {
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, label)
+}
+
+// GetWorkspaceLabels godoc
+//	@Summary		Get workspace labels
+//	@Description	Get the labels of a workspace ordered by name
+//	@Tags			labels
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Workspace ID"
+//	@Success		200	{array}		models.Label
+//	@Failure		400	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/workspaces/{id}/labels [get]
+func (h *Handler) GetWorkspaceLabels(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	labels, err := h.workspaces.GetWorkspaceLabels(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, labels)
+}
+
+// UpdateLabel godoc
+//	@Summary		Update label
+//	@Description	Rename or recolor a label
+//	@Tags			labels
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Workspace ID"
+//	@Param			label_id	path		string	true	"Label ID"
+//	@Param			label		body		object	true	"Any of name and color"
+//	@Success		200			{object}	models.Label
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		409			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/workspaces/{id}/labels/{label_id} [patch]
+func (h *Handler) UpdateLabel(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	labelId, err := getUUIDparam(c, "label_id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		Name  *string `json:"name" binding:"omitnil,min=1,max=50"`
+		Color *string `json:"color" binding:"omitnil,hexcolor"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	label, err := h.workspaces.UpdateLabel(c.Request.Context(), id, labelId, input.Name, input.Color)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		} else if errors.Is(err, services.ErrDuplicateEntry) {
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, label)
+}
+
+// DeleteLabel godoc
+//	@Summary		Delete label
+//	@Description	Delete a label and remove it from all tasks
+//	@Tags			labels
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id			path		string	true	"Workspace ID"
+//	@Param			label_id	path		string	true	"Label ID"
+//	@Success		200			{object}	map[string]string
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/workspaces/{id}/labels/{label_id} [delete]
+func (h *Handler) DeleteLabel(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	labelId, err := getUUIDparam(c, "label_id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	err = h.workspaces.DeleteLabel(c.Request.Context(), id, labelId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "label successfully deleted"})
+}
+
+// AttachTaskLabel godoc
+//	@Summary		Label task
+//	@Description	Attach a label of the task's workspace to a task
+//	@Tags			labels
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Task ID"
+//	@Param			label	body		object	true	"Label ID"
+//	@Success		201		{object}	map[string]string
+//	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		422		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/tasks/{id}/labels [post]
+func (h *Handler) AttachTaskLabel(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		LabelId uuid.UUID `json:"labelId" binding:"required"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	err = h.workspaces.AttachLabel(c.Request.Context(), id, input.LabelId)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidLabel) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error()})
+			return
+		} else if errors.Is(err, services.ErrDuplicateEntry) {
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "label successfully attached"})
+}
+
+// DetachTaskLabel godoc
+//	@Summary		Unlabel task
+//	@Description	Remove a label from a task
+//	@Tags			labels
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id			path		string	true	"Task ID"
+//	@Param			label_id	path		string	true	"Label ID"
+//	@Success		200			{object}	map[string]string
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/tasks/{id}/labels/{label_id} [delete]
+func (h *Handler) DetachTaskLabel(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	labelId, err := getUUIDparam(c, "label_id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	err = h.workspaces.DetachLabel(c.Request.Context(), id, labelId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "label successfully detached"})
+}
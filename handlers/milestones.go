@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateMilestone godoc
+//	@Summary		Create milestone
+//	@Description	Add a milestone to a project
+//	@Tags			milestones
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Project ID"
+//	@Param			milestone	body		object	true	"Milestone title, description and due date"
+//	@Success		201			{object}	models.Milestone
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/projects/{id}/milestones [post]
+func (h *Handler) CreateMilestone(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		Title       string      `json:"title" binding:"required,max=100"`
+		Description string      `json:"description"`
+		Due         models.Date `json:"due"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	milestone, err := h.workspaces.CreateMilestone(c.Request.Context(), id, input.Title, input.Description, input.Due)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, milestone)
+}
+
+// GetProjectMilestones godoc
+//	@Summary		Get project milestones
+//	@Description	Get the milestones of a project ordered by due date, with the percentage of their linked tasks that are done
+//	@Description	and whether they are overdue
+//	@Tags			milestones
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{array}		models.Milestone
+//	@Failure		400	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/projects/{id}/milestones [get]
+func (h *Handler) GetProjectMilestones(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	milestones, err := h.workspaces.GetProjectMilestones(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, milestones)
+}
+
+// GetMilestone godoc
+//	@Summary		Get milestone
+//	@Description	Get a milestone of a project with its progress
+//	@Tags			milestones
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id				path		string	true	"Project ID"
+//	@Param			milestone_id	path		string	true	"Milestone ID"
+//	@Success		200				{object}	models.Milestone
+//	@Failure		400				{object}	map[string]string
+//	@Failure		403				{object}	map[string]string
+//	@Failure		404				{object}	map[string]string
+//	@Failure		500				{object}	map[string]string
+//	@Router			/projects/{id}/milestones/{milestone_id} [get]
+func (h *Handler) GetMilestone(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	milestoneId, err := getUUIDparam(c, "milestone_id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	milestone, err := h.workspaces.GetMilestone(c.Request.Context(), id, milestoneId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, milestone)
+}
+
+// UpdateMilestone godoc
+//	@Summary		Update milestone
+//	@Description	Change the title, description or due date of a milestone
+//	@Tags			milestones
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string	true	"Project ID"
+//	@Param			milestone_id	path		string	true	"Milestone ID"
+//	@Param			milestone		body		object	true	"Any of title, description and due"
+//	@Success		200				{object}	models.Milestone
+//	@Failure		400				{object}	map[string]string
+//	@Failure		403				{object}	map[string]string
+//	@Failure		404				{object}	map[string]string
+//	@Failure		500				{object}	map[string]string
+//	@Router			/projects/{id}/milestones/{milestone_id} [patch]
+func (h *Handler) UpdateMilestone(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	milestoneId, err := getUUIDparam(c, "milestone_id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		Title       *string      `json:"title" binding:"omitnil,min=1,max=100"`
+		Description *string      `json:"description"`
+		Due         *models.Date `json:"due"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	milestone, err := h.workspaces.UpdateMilestone(c.Request.Context(), id, milestoneId, input.Title, input.Description, input.Due)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, milestone)
+}
+
+// DeleteMilestone godoc
+//	@Summary		Delete milestone
+//	@Description	Delete a milestone and unlink its tasks
+//	@Tags			milestones
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id				path		string	true	"Project ID"
+//	@Param			milestone_id	path		string	true	"Milestone ID"
+//	@Success		200				{object}	map[string]string
+//	@Failure		400				{object}	map[string]string
+//	@Failure		403				{object}	map[string]string
+//	@Failure		404				{object}	map[string]string
+//	@Failure		500				{object}	map[string]string
+//	@Router			/projects/{id}/milestones/{milestone_id} [delete]
+func (h *Handler) DeleteMilestone(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	milestoneId, err := getUUIDparam(c, "milestone_id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	err = h.workspaces.DeleteMilestone(c.Request.Context(), id, milestoneId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "milestone successfully deleted"})
+}
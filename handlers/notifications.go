@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/primekobie/hazel/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetNotifications godoc
+//	@Summary		Get notifications
+//	@Description	Get a page of the caller's notifications, newest first by default, with the number of unread notifications
+//	@Tags			notifications
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			unread	query		bool	false	"Only unread notifications"
+//	@Param			order	query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			cursor	query		string	false	"Cursor of the next page"
+//	@Success		200		{object}	models.NotificationPage
+//	@Failure		400		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/users/me/notifications [get]
+func (h *Handler) GetNotifications(c *gin.Context) {
+	opts, err := getListOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// notifications are read newest first unless asked otherwise
+	if c.Query("order") == "" {
+		opts.Desc = true
+	}
+
+	filter := models.NotificationFilter{ListOptions: opts}
+
+	switch c.Query("unread") {
+	case "", "false":
+	case "true":
+		filter.Unread = true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"message": "unread must be 'true' or 'false'"})
+		return
+	}
+
+	userId := uuid.MustParse(c.GetString("user_id"))
+	notifications, err := h.workspaces.GetNotifications(c.Request.Context(), userId, filter)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+}
+
+// MarkNotificationRead godoc
+//	@Summary		Mark notification read
+//	@Description	Mark one of the caller's notifications read
+//	@Tags			notifications
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Notification ID"
+//	@Success		200	{object}	map[string]string
+//	@Failure		400	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/users/me/notifications/{id}/read [post]
+func (h *Handler) MarkNotificationRead(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	userId := uuid.MustParse(c.GetString("user_id"))
+	err = h.workspaces.MarkNotificationRead(c.Request.Context(), userId, id)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "notification marked read"})
+}
+
+// MarkAllNotificationsRead godoc
+//	@Summary		Mark all notifications read
+//	@Description	Mark every unread notification of the caller read
+//	@Tags			notifications
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Success		200	{object}	map[string]int64
+//	@Failure		500	{object}	map[string]string
+//	@Router			/users/me/notifications/read [post]
+func (h *Handler) MarkAllNotificationsRead(c *gin.Context) {
+	userId := uuid.MustParse(c.GetString("user_id"))
+	count, err := h.workspaces.MarkAllNotificationsRead(c.Request.Context(), userId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"marked": count})
+}
+
+// GetNotificationSettings godoc
+//	@Summary		Get notification settings
+//	@Description	Get how the caller receives notification emails and whether they receive each type of notification in
+//	@Description	the app and by email
+//	@Tags			notifications
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Success		200	{object}	models.NotificationSettings
+//	@Failure		500	{object}	map[string]string
+//	@Router			/users/me/notifications/settings [get]
+func (h *Handler) GetNotificationSettings(c *gin.Context) {
+	userId := uuid.MustParse(c.GetString("user_id"))
+	settings, err := h.workspaces.GetNotificationSettings(c.Request.Context(), userId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateNotificationSettings godoc
+//	@Summary		Update notification settings
+//	@Description	Choose between an email per notification and a daily digest, and turn types of notifications on or off
+//	@Description	in the app and by email. Types not included keep their current setting.
+//	@Tags			notifications
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			settings	body		object	true	"Email delivery (immediate or digest) and preferences to change"
+//	@Success		200			{object}	models.NotificationSettings
+//	@Failure		400			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/users/me/notifications/settings [put]
+func (h *Handler) UpdateNotificationSettings(c *gin.Context) {
+	var input struct {
+		Delivery    *models.EmailDelivery           `json:"delivery"`
+		Preferences []models.NotificationPreference `json:"preferences"`
+	}
+
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	userId := uuid.MustParse(c.GetString("user_id"))
+	settings, err := h.workspaces.UpdateNotificationSettings(c.Request.Context(), userId, input.Delivery, input.Preferences)
+	if err != nil {
+		if errors.Is(err, services.ErrNotificationType) || errors.Is(err, services.ErrEmailDelivery) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
@@ -21,6 +21,7 @@ import (
 //	@Param			project	body		object	true	"Project info"
 //	@Success		201		{object}	models.Project
 //	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
 //	@Failure		500		{object}	map[string]string
 //	@Router			/projects [post]
 func (h *Handler) CreateProject(c *gin.Context) {
@@ -63,6 +64,7 @@ func (h *Handler) CreateProject(c *gin.Context) {
 //	@Success		200	{object}	models.Project
 //	@Failure		400	{object}	map[string]string
 //	@Failure		404	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
 //	@Failure		500	{object}	map[string]string
 //	@Router			/projects/{id} [get]
 func (h *Handler) GetProject(c *gin.Context) {
@@ -100,6 +102,7 @@ func (h *Handler) GetProject(c *gin.Context) {
 //	@Failure		400		{object}	map[string]string
 //	@Failure		404		{object}	map[string]string
 //	@Failure		422		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
 //	@Failure		500		{object}	map[string]string
 //	@Router			/projects/{id} [patch]
 func (h *Handler) UpdateProject(c *gin.Context) {
@@ -138,14 +141,20 @@ func (h *Handler) UpdateProject(c *gin.Context) {
 
 // GetProjectsInWorkspace godoc
 //	@Summary		Get projects in workspace
-//	@Description	Get all projects for a workspace
+//	@Description	Get a page of projects for a workspace
 //	@Tags			projects
 //	@Security		BearerAuth
 //	@Produce		json
-//	@Param			id	path		string	true	"Workspace ID"
-//	@Success		200	{array}		models.Project
-//	@Failure		400	{object}	map[string]string
+//	@Param			id		path		string	true	"Workspace ID"
+//	@Param			q		query		string	false	"Text to search in name and description"
+//	@Param			sort	query		string	false	"Sort field"	Enums(created_at, name, start_date, end_date)
+//	@Param			order	query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			cursor	query		string	false	"Cursor of the next page"
+//	@Success		200		{object}	models.Page[models.Project]
+//	@Failure		400		{object}	map[string]string
 //	@Failure		404	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
 //	@Failure		500	{object}	map[string]string
 //	@Router			/workspaces/{id}/projects [get]
 func (h *Handler) GetProjectsInWorkspace(c *gin.Context) {
@@ -156,11 +165,22 @@ func (h *Handler) GetProjectsInWorkspace(c *gin.Context) {
 		return
 	}
 
-	projects, err := h.workspaces.GetProjectsForWorkspace(c.Request.Context(), id)
+	opts, err := getListOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	filter := models.ProjectFilter{Search: c.Query("q"), ListOptions: opts}
+
+	projects, err := h.workspaces.GetProjectsForWorkspace(c.Request.Context(), id, filter)
 	if err != nil {
 		if errors.Is(err, models.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
 			return
+		} else if errors.Is(err, models.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
 		return
@@ -178,6 +198,7 @@ func (h *Handler) GetProjectsInWorkspace(c *gin.Context) {
 //	@Param			id	path		string	true	"Project ID"
 //	@Success		200	{object}	map[string]string
 //	@Failure		400	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
 //	@Failure		500	{object}	map[string]string
 //	@Router			/projects/{id} [delete]
 func (h *Handler) DeleteProject(c *gin.Context) {
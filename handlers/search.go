@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/primekobie/hazel/services"
+	"github.com/gin-gonic/gin"
+)
+
+// SearchWorkspace godoc
+//	@Summary		Search workspace
+//	@Description	Full-text search of the titles and descriptions of a workspace's tasks and the names and descriptions of its
+//	@Description	projects, best match first by default. q supports quoted phrases, "or" and "-" to exclude words. Titles and
+//	@Description	snippets are HTML escaped, with the matched words wrapped in <mark> tags.
+//	@Tags			workspaces
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id		path		string	true	"Workspace ID"
+//	@Param			q		query		string	true	"Search query"
+//	@Param			type	query		string	false	"Comma separated result types"	Enums(task, project)
+//	@Param			order	query		string	false	"Sort order"					Enums(asc, desc)
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			cursor	query		string	false	"Cursor of the next page"
+//	@Success		200		{object}	models.Page[models.SearchResult]
+//	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/workspaces/{id}/search [get]
+func (h *Handler) SearchWorkspace(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	opts, err := getListOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// results are read best match first unless asked otherwise
+	if c.Query("order") == "" {
+		opts.Desc = true
+	}
+
+	filter := models.SearchFilter{
+		Query:       c.Query("q"),
+		ListOptions: opts,
+	}
+
+	for _, t := range getQueryList(c, "type") {
+		filter.Types = append(filter.Types, models.Resource(t))
+	}
+
+	results, err := h.workspaces.Search(c.Request.Context(), id, filter)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidQuery) || errors.Is(err, services.ErrInvalidSearch) || errors.Is(err, services.ErrInvalidSearchType) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
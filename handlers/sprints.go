@@ -0,0 +1,332 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/primekobie/hazel/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// getSprintParams reads the project and sprint ids of a sprint route.
+func getSprintParams(c *gin.Context) (projectId, sprintId uuid.UUID, err error) {
+	projectId, err = getUUIDparam(c, "id")
+	if err != nil {
+		return projectId, sprintId, err
+	}
+
+	sprintId, err = getUUIDparam(c, "sprint_id")
+	return projectId, sprintId, err
+}
+
+// sprintError writes the response for an error returned by a sprint
+// operation.
+func sprintError(c *gin.Context, err error) {
+	if errors.Is(err, models.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+	} else if errors.Is(err, services.ErrInvalidDateRange) || errors.Is(err, services.ErrInvalidSprint) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error()})
+	} else if errors.Is(err, services.ErrSprintState) || errors.Is(err, services.ErrSprintActive) {
+		c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+	} else {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+	}
+}
+
+// CreateSprint godoc
+//	@Summary		Create sprint
+//	@Description	Plan a new sprint in a project
+//	@Tags			sprints
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Project ID"
+//	@Param			sprint	body		object	true	"Sprint name, goal, startDate and endDate"
+//	@Success		201		{object}	models.Sprint
+//	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		422		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/projects/{id}/sprints [post]
+func (h *Handler) CreateSprint(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		Name      string      `json:"name" binding:"required,max=100"`
+		Goal      string      `json:"goal"`
+		StartDate models.Date `json:"startDate"`
+		EndDate   models.Date `json:"endDate"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	sprint, err := h.workspaces.CreateSprint(c.Request.Context(), id, input.Name, input.Goal, input.StartDate, input.EndDate)
+	if err != nil {
+		sprintError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, sprint)
+}
+
+// GetProjectSprints godoc
+//	@Summary		Get project sprints
+//	@Description	Get the sprints of a project ordered by start date
+//	@Tags			sprints
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{array}		models.Sprint
+//	@Failure		400	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/projects/{id}/sprints [get]
+func (h *Handler) GetProjectSprints(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	sprints, err := h.workspaces.GetProjectSprints(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sprints)
+}
+
+// GetSprint godoc
+//	@Summary		Get sprint
+//	@Description	Get a sprint of a project
+//	@Tags			sprints
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id			path		string	true	"Project ID"
+//	@Param			sprint_id	path		string	true	"Sprint ID"
+//	@Success		200			{object}	models.Sprint
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/projects/{id}/sprints/{sprint_id} [get]
+func (h *Handler) GetSprint(c *gin.Context) {
+	id, sprintId, err := getSprintParams(c)
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	sprint, err := h.workspaces.GetSprint(c.Request.Context(), id, sprintId)
+	if err != nil {
+		sprintError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sprint)
+}
+
+// UpdateSprint godoc
+//	@Summary		Update sprint
+//	@Description	Change the name, goal or dates of a sprint that is not closed
+//	@Tags			sprints
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Project ID"
+//	@Param			sprint_id	path		string	true	"Sprint ID"
+//	@Param			sprint		body		object	true	"Any of name, goal, startDate and endDate"
+//	@Success		200			{object}	models.Sprint
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		409			{object}	map[string]string
+//	@Failure		422			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/projects/{id}/sprints/{sprint_id} [patch]
+func (h *Handler) UpdateSprint(c *gin.Context) {
+	id, sprintId, err := getSprintParams(c)
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		Name      *string      `json:"name" binding:"omitnil,min=1,max=100"`
+		Goal      *string      `json:"goal"`
+		StartDate *models.Date `json:"startDate"`
+		EndDate   *models.Date `json:"endDate"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	sprint, err := h.workspaces.UpdateSprint(c.Request.Context(), id, sprintId, input.Name, input.Goal, input.StartDate, input.EndDate)
+	if err != nil {
+		sprintError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sprint)
+}
+
+// DeleteSprint godoc
+//	@Summary		Delete sprint
+//	@Description	Delete a sprint and return its tasks to the backlog
+//	@Tags			sprints
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id			path		string	true	"Project ID"
+//	@Param			sprint_id	path		string	true	"Sprint ID"
+//	@Success		200			{object}	map[string]string
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/projects/{id}/sprints/{sprint_id} [delete]
+func (h *Handler) DeleteSprint(c *gin.Context) {
+	id, sprintId, err := getSprintParams(c)
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	err = h.workspaces.DeleteSprint(c.Request.Context(), id, sprintId)
+	if err != nil {
+		sprintError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "sprint successfully deleted"})
+}
+
+// StartSprint godoc
+//	@Summary		Start sprint
+//	@Description	Activate a planned sprint. The tasks it holds become its commitment. A project has at most one active sprint
+//	@Tags			sprints
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id			path		string	true	"Project ID"
+//	@Param			sprint_id	path		string	true	"Sprint ID"
+//	@Success		200			{object}	models.Sprint
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		409			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/projects/{id}/sprints/{sprint_id}/start [post]
+func (h *Handler) StartSprint(c *gin.Context) {
+	id, sprintId, err := getSprintParams(c)
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	sprint, err := h.workspaces.StartSprint(c.Request.Context(), id, sprintId)
+	if err != nil {
+		sprintError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sprint)
+}
+
+// CloseSprint godoc
+//	@Summary		Close sprint
+//	@Description	Close the active sprint. Tasks that are not done move to the open sprint carryOverTo, or to the backlog if it is omitted
+//	@Tags			sprints
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Project ID"
+//	@Param			sprint_id	path		string	true	"Sprint ID"
+//	@Param			close		body		object	false	"Optional carryOverTo sprint ID"
+//	@Success		200			{object}	map[string]any
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		409			{object}	map[string]string
+//	@Failure		422			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/projects/{id}/sprints/{sprint_id}/close [post]
+func (h *Handler) CloseSprint(c *gin.Context) {
+	id, sprintId, err := getSprintParams(c)
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		CarryOverTo uuid.UUID `json:"carryOverTo"`
+	}
+
+	// the body is optional
+	if c.Request.ContentLength != 0 {
+		err = c.ShouldBindJSON(&input)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+	}
+
+	sprint, carried, err := h.workspaces.CloseSprint(c.Request.Context(), id, sprintId, input.CarryOverTo)
+	if err != nil {
+		sprintError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sprint": sprint, "carriedOver": carried})
+}
+
+// GetSprintSummary godoc
+//	@Summary		Get sprint summary
+//	@Description	Count the tasks a sprint committed to, added, removed and completed
+//	@Tags			sprints
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id			path		string	true	"Project ID"
+//	@Param			sprint_id	path		string	true	"Sprint ID"
+//	@Success		200			{object}	models.SprintSummary
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/projects/{id}/sprints/{sprint_id}/summary [get]
+func (h *Handler) GetSprintSummary(c *gin.Context) {
+	id, sprintId, err := getSprintParams(c)
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	summary, err := h.workspaces.GetSprintSummary(c.Request.Context(), id, sprintId)
+	if err != nil {
+		sprintError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/primekobie/hazel/services"
+	"github.com/gin-gonic/gin"
+)
+
+// isWorkflowError reports whether err rejects a change to a project's
+// workflow or a task's status.
+func isWorkflowError(err error) bool {
+	return errors.Is(err, services.ErrInvalidStatus) || errors.Is(err, services.ErrInvalidStatusKey) ||
+		errors.Is(err, services.ErrInvalidCategory) || errors.Is(err, services.ErrTransitionDenied) ||
+		errors.Is(err, services.ErrInvalidTransition)
+}
+
+// GetProjectStatuses godoc
+//	@Summary		Get project statuses
+//	@Description	Get the statuses of a project's workflow in order
+//	@Tags			workflows
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{array}		models.ProjectStatus
+//	@Failure		400	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/projects/{id}/statuses [get]
+func (h *Handler) GetProjectStatuses(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	statuses, err := h.workspaces.GetProjectStatuses(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, statuses)
+}
+
+// CreateProjectStatus godoc
+//	@Summary		Add project status
+//	@Description	Add a status to the end of a project's workflow. The key is what tasks store as their status and cannot be changed later
+//	@Tags			workflows
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Project ID"
+//	@Param			status	body		object	true	"Status key, name and category (open, in_progress or done)"
+//	@Success		201		{object}	models.ProjectStatus
+//	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		422		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/projects/{id}/statuses [post]
+func (h *Handler) CreateProjectStatus(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		Key      string                `json:"key" binding:"required,max=32"`
+		Name     string                `json:"name" binding:"required,max=50"`
+		Category models.StatusCategory `json:"category" binding:"required"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	status, err := h.workspaces.CreateStatus(c.Request.Context(), id, input.Key, input.Name, input.Category)
+	if err != nil {
+		if isWorkflowError(err) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error()})
+			return
+		} else if errors.Is(err, services.ErrDuplicateEntry) {
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, status)
+}
+
+// UpdateProjectStatus godoc
+//	@Summary		Update project status
+//	@Description	Rename, recategorize or reorder a status of a project's workflow
+//	@Tags			workflows
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Project ID"
+//	@Param			status_id	path		string	true	"Status ID"
+//	@Param			status		body		object	true	"Any of name, category and position"
+//	@Success		200			{object}	models.ProjectStatus
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		422			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/projects/{id}/statuses/{status_id} [patch]
+func (h *Handler) UpdateProjectStatus(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	statusId, err := getUUIDparam(c, "status_id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		Name     *string                `json:"name" binding:"omitnil,min=1,max=50"`
+		Category *models.StatusCategory `json:"category"`
+		Position *int                   `json:"position" binding:"omitnil,min=0"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	status, err := h.workspaces.UpdateStatus(c.Request.Context(), id, statusId, input.Name, input.Category, input.Position)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		} else if isWorkflowError(err) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// DeleteProjectStatus godoc
+//	@Summary		Delete project status
+//	@Description	Remove a status from a project's workflow. Tasks must be moved out of the status first
+//	@Tags			workflows
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id			path		string	true	"Project ID"
+//	@Param			status_id	path		string	true	"Status ID"
+//	@Success		200			{object}	map[string]string
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		409			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/projects/{id}/statuses/{status_id} [delete]
+func (h *Handler) DeleteProjectStatus(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	statusId, err := getUUIDparam(c, "status_id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	err = h.workspaces.DeleteStatus(c.Request.Context(), id, statusId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		} else if errors.Is(err, services.ErrStatusInUse) || errors.Is(err, services.ErrLastStatus) {
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "status successfully deleted"})
+}
+
+// GetStatusTransitions godoc
+//	@Summary		Get status transitions
+//	@Description	Get the status changes allowed by a project's workflow. An empty list allows any change
+//	@Tags			workflows
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{array}		models.StatusTransition
+//	@Failure		400	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/projects/{id}/transitions [get]
+func (h *Handler) GetStatusTransitions(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	transitions, err := h.workspaces.GetStatusTransitions(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transitions)
+}
+
+// SetStatusTransitions godoc
+//	@Summary		Set status transitions
+//	@Description	Replace the status changes allowed by a project's workflow. An empty list allows any change
+//	@Tags			workflows
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Project ID"
+//	@Param			transitions	body		object	true	"List of from and to status keys"
+//	@Success		200			{array}		models.StatusTransition
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		422			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/projects/{id}/transitions [put]
+func (h *Handler) SetStatusTransitions(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		Transitions []models.StatusTransition `json:"transitions" binding:"omitnil,dive"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	transitions, err := h.workspaces.SetStatusTransitions(c.Request.Context(), id, input.Transitions)
+	if err != nil {
+		if isWorkflowError(err) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transitions)
+}
@@ -14,7 +14,9 @@ import (
 
 // CreateTask godoc
 //	@Summary		Create task
-//	@Description	Create a new task in a project
+//	@Description	Create a new task in a project, optionally as a subtask of another task in the project, in one of its open sprints
+//	@Description	or linked to one of its milestones.
+//	@Description	The task starts in the first open status of the project's workflow
 //	@Security		BearerAuth
 //	@Tags			tasks
 //	@Accept			json
@@ -22,15 +24,22 @@ import (
 //	@Param			task	body		object	true	"Task info"
 //	@Success		201		{object}	models.Task
 //	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		422		{object}	map[string]string
 //	@Failure		500		{object}	map[string]string
 //	@Router			/tasks [post]
 func (h *Handler) CreateTask(c *gin.Context) {
 	var input struct {
 		ProjectId   uuid.UUID           `json:"projectId" binding:"required,uuid"`
+		ParentId    uuid.UUID           `json:"parentId"`
+		SprintId    uuid.UUID           `json:"sprintId"`
+		MilestoneId uuid.UUID           `json:"milestoneId"`
 		Title       string              `json:"title" binding:"required"`
 		Description string              `json:"description"`
 		Due         time.Time           `json:"due"`
 		Priority    models.TaskPriority `json:"priority"`
+		Estimate    int64               `json:"estimate" binding:"min=0"`
 	}
 
 	err := c.ShouldBindJSON(&input)
@@ -42,11 +51,22 @@ func (h *Handler) CreateTask(c *gin.Context) {
 		Title:       input.Title,
 		Description: input.Description,
 		Project:     &models.Project{Id: input.ProjectId},
+		ParentId:    input.ParentId,
+		SprintId:    input.SprintId,
+		MilestoneId: input.MilestoneId,
 		Due:         input.Due,
 		Priority:    input.Priority,
+		Estimate:    input.Estimate,
 	}
 	err = h.workspaces.CreateTask(c.Request.Context(), task)
 	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		} else if isHierarchyError(err) || isPlanningError(err) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
 		return
 	}
@@ -64,6 +84,7 @@ func (h *Handler) CreateTask(c *gin.Context) {
 //	@Success		200	{object}	models.Task
 //	@Failure		400	{object}	map[string]string
 //	@Failure		404	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
 //	@Failure		500	{object}	map[string]string
 //	@Router			/tasks/{id} [get]
 func (h *Handler) GetTask(c *gin.Context) {
@@ -90,7 +111,12 @@ func (h *Handler) GetTask(c *gin.Context) {
 
 // UpdateTask godoc
 //	@Summary		Update task
-//	@Description	Update task details
+//	@Description	Update task details. Setting parentId moves the task under another task in the project; null makes it a top-level task.
+//	@Description	Setting sprintId plans the task into an open sprint of the project; null returns it to the backlog.
+//	@Description	Setting milestoneId links the task to a milestone of the project; null unlinks it.
+//	@Description	estimate is the planned time in seconds.
+//	@Description	The status must be a key of the project's workflow that the workflow's transitions allow moving to.
+//	@Description	A task with open blockers can only be moved to a done status with "force": true
 //	@Security		BearerAuth
 //	@Tags			tasks
 //	@Accept			json
@@ -100,7 +126,9 @@ func (h *Handler) GetTask(c *gin.Context) {
 //	@Success		200		{object}	models.Task
 //	@Failure		400		{object}	map[string]string
 //	@Failure		404		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
 //	@Failure		422		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
 //	@Failure		500		{object}	map[string]string
 //	@Router			/tasks/{id} [patch]
 func (h *Handler) UpdateTask(c *gin.Context) {
@@ -126,9 +154,12 @@ func (h *Handler) UpdateTask(c *gin.Context) {
 		if errors.Is(err, models.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
 			return
-		} else if errors.Is(err, services.ErrInvalidDateFormat) {
+		} else if errors.Is(err, services.ErrInvalidDateFormat) || errors.Is(err, services.ErrInvalidEstimate) || isHierarchyError(err) || isWorkflowError(err) || isPlanningError(err) {
 			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error()})
 			return
+		} else if errors.Is(err, services.ErrTaskBlocked) {
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
 		return
@@ -137,16 +168,129 @@ func (h *Handler) UpdateTask(c *gin.Context) {
 	c.JSON(http.StatusOK, task)
 }
 
-// GetProjectTasks godoc
-//	@Summary		Get project tasks
-//	@Description	Get all tasks for a project
+// isHierarchyError reports whether err rejects a task's parent.
+func isHierarchyError(err error) bool {
+	return errors.Is(err, services.ErrInvalidParent) || errors.Is(err, services.ErrTaskCycle) || errors.Is(err, services.ErrTaskTooDeep)
+}
+
+// MoveTask godoc
+//	@Summary		Move task
+//	@Description	Place a task in its status column, or in the column of another status, directly after afterId or before beforeId.
+//	@Description	Without either the task goes to the end of the column; with both they have to be neighbours.
+//	@Description	A task with open blockers can only be moved to a done status with "force": true
 //	@Security		BearerAuth
 //	@Tags			tasks
+//	@Accept			json
 //	@Produce		json
-//	@Param			id	path		string	true	"Project ID"
+//	@Param			id		path		string	true	"Task ID"
+//	@Param			move	body		object	true	"Optional status, afterId, beforeId and force"
+//	@Success		200		{object}	models.Task
+//	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		422		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/tasks/{id}/move [post]
+func (h *Handler) MoveTask(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		Status   models.TaskStatus `json:"status"`
+		AfterId  uuid.UUID         `json:"afterId"`
+		BeforeId uuid.UUID         `json:"beforeId"`
+		Force    bool              `json:"force"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	task, err := h.workspaces.MoveTask(c.Request.Context(), id, input.Status, input.AfterId, input.BeforeId, input.Force)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		} else if isWorkflowError(err) || errors.Is(err, services.ErrInvalidPosition) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error()})
+			return
+		} else if errors.Is(err, services.ErrTaskBlocked) {
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// isPlanningError reports whether err rejects a task's sprint or milestone.
+func isPlanningError(err error) bool {
+	return errors.Is(err, services.ErrInvalidSprint) || errors.Is(err, services.ErrInvalidMilestone)
+}
+
+// GetSubtasks godoc
+//	@Summary		Get subtasks
+//	@Description	Get the direct subtasks of a task with their progress
+//	@Security		BearerAuth
+//	@Tags			tasks
+//	@Produce		json
+//	@Param			id	path		string	true	"Task ID"
 //	@Success		200	{array}		models.Task
 //	@Failure		400	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/tasks/{id}/subtasks [get]
+func (h *Handler) GetSubtasks(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	tasks, err := h.workspaces.GetSubtasks(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// GetProjectTasks godoc
+//	@Summary		Get project tasks
+//	@Description	Get a page of tasks for a project, optionally filtered and sorted. Tasks are in board order by default
+//	@Security		BearerAuth
+//	@Tags			tasks
+//	@Produce		json
+//	@Param			id			path		string	true	"Project ID"
+//	@Param			status		query		string	false	"Comma separated statuses"
+//	@Param			priority	query		string	false	"Comma separated priorities"
+//	@Param			assignee	query		string	false	"Assigned user ID, or me for the caller's tasks"
+//	@Param			labels		query		string	false	"Comma separated label IDs; tasks with any of them match"
+//	@Param			sprint		query		string	false	"Sprint ID, or backlog for tasks in no sprint"
+//	@Param			milestone	query		string	false	"Milestone ID"
+//	@Param			dueFrom		query		string	false	"Due on or after (YYYY-MM-DD or RFC 3339)"
+//	@Param			dueTo		query		string	false	"Due on or before (YYYY-MM-DD or RFC 3339)"
+//	@Param			overdue		query		bool	false	"Only tasks past due and not done"
+//	@Param			q			query		string	false	"Text to search in title and description"
+//	@Param			sort		query		string	false	"Sort field"	Enums(rank, created_at, due, priority)
+//	@Param			order		query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param			limit		query		int		false	"Page size"
+//	@Param			cursor		query		string	false	"Cursor of the next page"
+//	@Success		200			{object}	models.Page[models.Task]
+//	@Failure		400			{object}	map[string]string
 //	@Failure		404	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
 //	@Failure		500	{object}	map[string]string
 //	@Router			/projects/{id}/tasks [get]
 func (h *Handler) GetProjectTasks(c *gin.Context) {
@@ -158,11 +302,20 @@ func (h *Handler) GetProjectTasks(c *gin.Context) {
 		return
 	}
 
-	tasks, err := h.workspaces.GetProjectTasks(c.Request.Context(), id)
+	filter, err := getTaskFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	tasks, err := h.workspaces.GetProjectTasks(c.Request.Context(), id, filter)
 	if err != nil {
 		if errors.Is(err, models.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
 			return
+		} else if errors.Is(err, models.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
 		return
@@ -180,6 +333,7 @@ func (h *Handler) GetProjectTasks(c *gin.Context) {
 //	@Param			id	path		string	true	"Task ID"
 //	@Success		200	{object}	map[string]string
 //	@Failure		400	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
 //	@Failure		500	{object}	map[string]string
 //	@Router			/tasks/{id} [delete]
 func (h *Handler) DeleteTask(c *gin.Context) {
@@ -200,7 +354,7 @@ func (h *Handler) DeleteTask(c *gin.Context) {
 
 // AssignTaskToUser godoc
 //	@Summary		Assign task to user
-//	@Description	Assign a task to a user
+//	@Description	Assign a task to a member of its workspace
 //	@Tags			tasks
 //	@Security		BearerAuth
 //	@Accept			json
@@ -210,6 +364,8 @@ func (h *Handler) DeleteTask(c *gin.Context) {
 //	@Success		200			{object}	map[string]string
 //	@Failure		400			{object}	map[string]string
 //	@Failure		422			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
 //	@Failure		500			{object}	map[string]string
 //	@Router			/tasks/{id}/assignments [post]
 func (h *Handler) AssignTaskToUser(c *gin.Context) {
@@ -232,6 +388,10 @@ func (h *Handler) AssignTaskToUser(c *gin.Context) {
 
 	err = h.workspaces.AssignTaskToUser(c.Request.Context(), id, input.UserId)
 	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
 		if errors.Is(err, services.ErrFailedOperation) {
 			c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
 			return
@@ -255,6 +415,7 @@ func (h *Handler) AssignTaskToUser(c *gin.Context) {
 //	@Param			user_id	path		string	true	"User ID"
 //	@Success		200		{object}	map[string]string
 //	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
 //	@Failure		500		{object}	map[string]string
 //	@Router			/tasks/{id}/assignments/{user_id} [delete]
 func (h *Handler) RemoveAssignment(c *gin.Context) {
@@ -291,6 +452,7 @@ func (h *Handler) RemoveAssignment(c *gin.Context) {
 //	@Success		200	{array}		models.User
 //	@Failure		400	{object}	map[string]string
 //	@Failure		404	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
 //	@Failure		500	{object}	map[string]string
 //	@Router			/tasks/{id}/assignments [get]
 func (h *Handler) GetAssignedUsers(c *gin.Context) {
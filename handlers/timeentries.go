@@ -0,0 +1,416 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/primekobie/hazel/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// getTimeEntryParams reads the task and time entry ids from the path.
+func getTimeEntryParams(c *gin.Context) (taskId, entryId uuid.UUID, err error) {
+	taskId, err = getUUIDparam(c, "id")
+	if err != nil {
+		return taskId, entryId, err
+	}
+
+	entryId, err = getUUIDparam(c, "entry_id")
+	return taskId, entryId, err
+}
+
+// timeEntryError writes the response for an error returned by a time
+// tracking operation.
+func timeEntryError(c *gin.Context, err error) {
+	if errors.Is(err, models.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+	} else if errors.Is(err, services.ErrPermissionDenied) {
+		c.JSON(http.StatusForbidden, gin.H{"message": err.Error()})
+	} else if errors.Is(err, services.ErrInvalidDuration) || errors.Is(err, services.ErrEntryRunning) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error()})
+	} else if errors.Is(err, services.ErrTimerRunning) || errors.Is(err, services.ErrNoRunningTimer) {
+		c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+	} else {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+	}
+}
+
+// StartTimer godoc
+//	@Summary		Start timer
+//	@Description	Start tracking time on a task. A user can only run one timer at a time.
+//	@Tags			time
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Task ID"
+//	@Param			timer	body		object	false	"Optional note"
+//	@Success		201		{object}	models.TimeEntry
+//	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/tasks/{id}/time/start [post]
+func (h *Handler) StartTimer(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		Note string `json:"note"`
+	}
+
+	// the body is optional
+	if c.Request.ContentLength != 0 {
+		err = c.ShouldBindJSON(&input)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+	}
+
+	userId := uuid.MustParse(c.GetString("user_id"))
+	entry, err := h.workspaces.StartTimer(c.Request.Context(), id, userId, input.Note)
+	if err != nil {
+		timeEntryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// StopTimer godoc
+//	@Summary		Stop timer
+//	@Description	Stop the caller's timer on a task and log the time since it was started
+//	@Tags			time
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Task ID"
+//	@Success		200	{object}	models.TimeEntry
+//	@Failure		400	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		409	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/tasks/{id}/time/stop [post]
+func (h *Handler) StopTimer(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	userId := uuid.MustParse(c.GetString("user_id"))
+	entry, err := h.workspaces.StopTimer(c.Request.Context(), id, userId)
+	if err != nil {
+		timeEntryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// GetRunningTimer godoc
+//	@Summary		Get running timer
+//	@Description	Get the timer the caller has not stopped yet
+//	@Tags			time
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Success		200	{object}	models.TimeEntry
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/users/me/timer [get]
+func (h *Handler) GetRunningTimer(c *gin.Context) {
+	userId := uuid.MustParse(c.GetString("user_id"))
+	entry, err := h.workspaces.GetRunningTimer(c.Request.Context(), userId)
+	if err != nil {
+		timeEntryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// LogTime godoc
+//	@Summary		Log time
+//	@Description	Log time spent on a task without a timer. duration is in seconds and may be at most 24 hours;
+//	@Description	entries without a startedAt end at the time they are logged.
+//	@Tags			time
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Task ID"
+//	@Param			entry	body		object	true	"Duration, optional startedAt and note"
+//	@Success		201		{object}	models.TimeEntry
+//	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		422		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/tasks/{id}/time [post]
+func (h *Handler) LogTime(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		Duration  int64     `json:"duration" binding:"required"`
+		StartedAt time.Time `json:"startedAt"`
+		Note      string    `json:"note"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	userId := uuid.MustParse(c.GetString("user_id"))
+	entry, err := h.workspaces.LogTime(c.Request.Context(), id, userId, input.StartedAt, input.Duration, input.Note)
+	if err != nil {
+		timeEntryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// GetTaskTimeEntries godoc
+//	@Summary		Get task time entries
+//	@Description	Get the time logged on a task, most recent first. Running timers have no endedAt.
+//	@Tags			time
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Task ID"
+//	@Success		200	{array}		models.TimeEntry
+//	@Failure		400	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/tasks/{id}/time [get]
+func (h *Handler) GetTaskTimeEntries(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	entries, err := h.workspaces.GetTaskTimeEntries(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// UpdateTimeEntry godoc
+//	@Summary		Update time entry
+//	@Description	Change the note, start time or duration of a time entry. Allowed for the user who logged it and workspace admins.
+//	@Tags			time
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Task ID"
+//	@Param			entry_id	path		string	true	"Time entry ID"
+//	@Param			entry		body		object	true	"Any of note, startedAt and duration"
+//	@Success		200			{object}	models.TimeEntry
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		422			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/tasks/{id}/time/{entry_id} [patch]
+func (h *Handler) UpdateTimeEntry(c *gin.Context) {
+	taskId, entryId, err := getTimeEntryParams(c)
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		Note      *string    `json:"note"`
+		StartedAt *time.Time `json:"startedAt"`
+		Duration  *int64     `json:"duration"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	userId := uuid.MustParse(c.GetString("user_id"))
+	role := c.MustGet("workspace_role").(models.Role)
+
+	entry, err := h.workspaces.UpdateTimeEntry(c.Request.Context(), taskId, entryId, userId, role, input.Note, input.StartedAt, input.Duration)
+	if err != nil {
+		timeEntryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// DeleteTimeEntry godoc
+//	@Summary		Delete time entry
+//	@Description	Delete a time entry. Allowed for the user who logged it and workspace admins.
+//	@Tags			time
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id			path		string	true	"Task ID"
+//	@Param			entry_id	path		string	true	"Time entry ID"
+//	@Success		200			{object}	map[string]string
+//	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/tasks/{id}/time/{entry_id} [delete]
+func (h *Handler) DeleteTimeEntry(c *gin.Context) {
+	taskId, entryId, err := getTimeEntryParams(c)
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	userId := uuid.MustParse(c.GetString("user_id"))
+	role := c.MustGet("workspace_role").(models.Role)
+
+	err = h.workspaces.DeleteTimeEntry(c.Request.Context(), taskId, entryId, userId, role)
+	if err != nil {
+		timeEntryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "time entry successfully deleted"})
+}
+
+// getTimeReportFilter reads the user, from and to report filters from the
+// query string.
+func getTimeReportFilter(c *gin.Context) (models.TimeReportFilter, error) {
+	var filter models.TimeReportFilter
+	var err error
+
+	if user := c.Query("user"); user != "" {
+		filter.UserId, err = uuid.Parse(user)
+		if err != nil {
+			return filter, errors.New("user must be a valid user id")
+		}
+	}
+
+	filter.From, err = getQueryTime(c, "from", false)
+	if err != nil {
+		return filter, err
+	}
+
+	filter.To, err = getQueryTime(c, "to", true)
+	if err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+// writeTimeReport builds the report for filter and writes the response.
+func (h *Handler) writeTimeReport(c *gin.Context, filter models.TimeReportFilter) {
+	report, err := h.workspaces.GetTimeReport(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDateRange) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetWorkspaceTimeReport godoc
+//	@Summary		Get workspace time report
+//	@Description	Sum the time logged in a workspace, in total and per project, user and day. Entries are counted by the day they
+//	@Description	started; running timers are not counted. Durations are in seconds.
+//	@Tags			time
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id		path		string	true	"Workspace ID"
+//	@Param			project	query		string	false	"Project ID"
+//	@Param			user	query		string	false	"User ID"
+//	@Param			from	query		string	false	"Count entries started on or after this date (YYYY-MM-DD) or RFC 3339 time"
+//	@Param			to		query		string	false	"Count entries started up to and including this date (YYYY-MM-DD), or before this RFC 3339 time"
+//	@Success		200		{object}	models.TimeReport
+//	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		422		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/workspaces/{id}/time [get]
+func (h *Handler) GetWorkspaceTimeReport(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	filter, err := getTimeReportFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+	filter.WorkspaceId = id
+
+	if project := c.Query("project"); project != "" {
+		filter.ProjectId, err = uuid.Parse(project)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "project must be a valid project id"})
+			return
+		}
+	}
+
+	h.writeTimeReport(c, filter)
+}
+
+// GetProjectTimeReport godoc
+//	@Summary		Get project time report
+//	@Description	Sum the time logged on the tasks of a project, in total and per user and day. Entries are counted by the day they
+//	@Description	started; running timers are not counted. Durations are in seconds.
+//	@Tags			time
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id		path		string	true	"Project ID"
+//	@Param			user	query		string	false	"User ID"
+//	@Param			from	query		string	false	"Count entries started on or after this date (YYYY-MM-DD) or RFC 3339 time"
+//	@Param			to		query		string	false	"Count entries started up to and including this date (YYYY-MM-DD), or before this RFC 3339 time"
+//	@Success		200		{object}	models.TimeReport
+//	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		422		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/projects/{id}/time [get]
+func (h *Handler) GetProjectTimeReport(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	filter, err := getTimeReportFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+	filter.ProjectId = id
+
+	h.writeTimeReport(c, filter)
+}
@@ -52,7 +52,7 @@ func (h *Handler) CreateUser(c *gin.Context) {
 
 // VerifyUser godoc
 //	@Summary		Verify user email
-//	@Description	Verify a user's email with a code
+//	@Description	Verify a user's email with a code. Pending workspace invitations sent to the email are accepted
 //	@Tags			users
 //	@Accept			json
 //	@Produce		json
@@ -82,6 +82,9 @@ func (h *Handler) VerifyUser(c *gin.Context) {
 		return
 	}
 
+	// the email is now proven, so pending invitations sent to it can be honoured
+	h.workspaces.JoinInvitedWorkspaces(c.Request.Context(), &user)
+
 	c.JSON(http.StatusOK, gin.H{"user": user})
 }
 
@@ -149,7 +152,7 @@ func (h *Handler) LoginUser(c *gin.Context) {
 		return
 	}
 
-	session, err := h.users.NewSession(c.Request.Context(), input.Email, input.Password)
+	session, err := h.users.NewSession(c.Request.Context(), input.Email, input.Password, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
 		if errors.Is(err, services.ErrFailedOperation) {
 			c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
@@ -164,7 +167,7 @@ func (h *Handler) LoginUser(c *gin.Context) {
 
 // GetUserAccessToken godoc
 //	@Summary		Refresh access token
-//	@Description	Get a new access token using a refresh token
+//	@Description	Get a new access token using a refresh token. The refresh token is rotated and the old one can no longer be used
 //	@Tags			users
 //	@Accept			json
 //	@Produce		json
@@ -199,6 +202,185 @@ func (h *Handler) GetUserAccessToken(c *gin.Context) {
 
 }
 
+// LogoutUser godoc
+//	@Summary		Logout user
+//	@Description	End the session a refresh token belongs to
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			refreshToken	body		object	true	"Refresh token"
+//	@Success		200				{object}	map[string]string
+//	@Failure		400				{object}	map[string]string
+//	@Failure		401				{object}	map[string]string
+//	@Failure		500				{object}	map[string]string
+//	@Router			/auth/logout [post]
+func (h *Handler) LogoutUser(c *gin.Context) {
+	var input struct {
+		RefreshToken string `json:"refreshToken" binding:"required,jwt"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	err := h.users.Logout(c.Request.Context(), input.RefreshToken)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidToken) || errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusUnauthorized, gin.H{"message": services.ErrInvalidToken.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "logged out successfully"})
+}
+
+// GetUserSessions godoc
+//	@Summary		Get my sessions
+//	@Description	List the authenticated user's active sessions
+//	@Tags			users
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Success		200	{array}		models.Session
+//	@Failure		500	{object}	map[string]string
+//	@Router			/users/me/sessions [get]
+func (h *Handler) GetUserSessions(c *gin.Context) {
+	userId := uuid.MustParse(c.GetString("user_id"))
+
+	sessions, err := h.users.GetSessions(c.Request.Context(), userId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// DeleteUserSession godoc
+//	@Summary		Revoke session
+//	@Description	Sign the authenticated user out of one of their sessions
+//	@Tags			users
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Session ID"
+//	@Success		200	{object}	map[string]string
+//	@Failure		400	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/users/me/sessions/{id} [delete]
+func (h *Handler) DeleteUserSession(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	userId := uuid.MustParse(c.GetString("user_id"))
+
+	err = h.users.RevokeSession(c.Request.Context(), userId, id)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "session successfully revoked"})
+}
+
+// DeleteAllUserSessions godoc
+//	@Summary		Log out everywhere
+//	@Description	Revoke every session of the authenticated user
+//	@Tags			users
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Success		200	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/users/me/sessions [delete]
+func (h *Handler) DeleteAllUserSessions(c *gin.Context) {
+	userId := uuid.MustParse(c.GetString("user_id"))
+
+	err := h.users.RevokeAllSessions(c.Request.Context(), userId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "all sessions successfully revoked"})
+}
+
+// ForgotPassword godoc
+//	@Summary		Request password reset
+//	@Description	Email a one-time password reset code to the user
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			email	body		object	true	"User email"
+//	@Success		202		{object}	map[string]string
+//	@Failure		400		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/auth/password/forgot [post]
+func (h *Handler) ForgotPassword(c *gin.Context) {
+	var input struct {
+		Email string `json:"email" binding:"required,email"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// unknown addresses get the same response so accounts cannot be enumerated
+	err := h.users.RequestPasswordReset(c.Request.Context(), input.Email)
+	if err != nil && !errors.Is(err, models.ErrNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": fmt.Sprintf("if an account exists for '%s', a password reset code has been sent to it", input.Email)})
+}
+
+// ResetPassword godoc
+//	@Summary		Reset password
+//	@Description	Set a new password using a password reset code and sign out of all sessions
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			reset	body		object	true	"Email, reset code and new password"
+//	@Success		200		{object}	map[string]string
+//	@Failure		400		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/auth/password/reset [post]
+func (h *Handler) ResetPassword(c *gin.Context) {
+	var input struct {
+		Email    string `json:"email" binding:"required,email"`
+		Code     string `json:"code" binding:"required"`
+		Password string `json:"password" binding:"required,min=8,max=20"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	err := h.users.ResetPassword(c.Request.Context(), input.Email, input.Code, input.Password)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidToken) || errors.Is(err, services.ErrInvalidPassword) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "password has been reset; please log in again"})
+}
+
 // GetUser godoc
 //	@Summary		Get user by ID
 //	@Description	Get user details by user ID
@@ -233,7 +415,7 @@ func (h *Handler) GetUser(c *gin.Context) {
 
 // UpdateUserData godoc
 //	@Summary		Update user data
-//	@Description	Update user profile information
+//	@Description	Update the user's name or password. Profile photos are uploaded to /users/profile/photo.
 //	@Tags			users
 //	@Security		BearerAuth
 //	@Accept			json
@@ -275,6 +457,104 @@ func (h *Handler) UpdateUserData(c *gin.Context) {
 	c.JSON(http.StatusOK, user)
 }
 
+// SetProfilePhoto godoc
+//	@Summary		Upload profile photo
+//	@Description	Upload a PNG, JPEG, GIF or WebP image of at most 5 MB as the user's profile photo, replacing the current one
+//	@Tags			users
+//	@Security		BearerAuth
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			file	formData	file	true	"Profile photo"
+//	@Success		200		{object}	models.User
+//	@Failure		400		{object}	map[string]string
+//	@Failure		413		{object}	map[string]string
+//	@Failure		415		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/users/profile/photo [put]
+func (h *Handler) SetProfilePhoto(c *gin.Context) {
+	header, ok := getUploadedFile(c, services.MaxPhotoSize)
+	if !ok {
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		slog.Error("failed to open uploaded file", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+	defer file.Close()
+
+	userId := uuid.MustParse(c.GetString("user_id"))
+	user, err := h.users.SetProfilePhoto(c.Request.Context(), userId, file)
+	if err != nil {
+		uploadError(c, err, services.MaxPhotoSize)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// DeleteProfilePhoto godoc
+//	@Summary		Remove profile photo
+//	@Description	Remove the user's profile photo
+//	@Tags			users
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Success		200	{object}	models.User
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/users/profile/photo [delete]
+func (h *Handler) DeleteProfilePhoto(c *gin.Context) {
+	userId := uuid.MustParse(c.GetString("user_id"))
+	user, err := h.users.RemoveProfilePhoto(c.Request.Context(), userId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// GetProfilePhoto godoc
+//	@Summary		Get profile photo
+//	@Description	Stream a user's profile photo
+//	@Tags			users
+//	@Security		BearerAuth
+//	@Produce		image/png,image/jpeg,image/gif,image/webp
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{file}		file
+//	@Failure		400	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/users/{id}/photo [get]
+func (h *Handler) GetProfilePhoto(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id"})
+		return
+	}
+
+	contentType, photo, err := h.users.OpenProfilePhoto(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		return
+	}
+	defer photo.Close()
+
+	c.DataFromReader(http.StatusOK, -1, contentType, photo, map[string]string{
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
 // DeleteUser godoc
 //	@Summary		Delete user
 //	@Description	Delete a user by ID
@@ -285,6 +565,7 @@ func (h *Handler) UpdateUserData(c *gin.Context) {
 //	@Success		200	{object}	map[string]string
 //	@Failure		400	{object}	map[string]string
 //	@Failure		404	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
 //	@Failure		500	{object}	map[string]string
 //	@Router			/users/{id} [delete]
 func (h *Handler) DeleteUser(c *gin.Context) {
@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/primekobie/hazel/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// viewError writes the response for an error returned by a saved view
+// operation.
+func viewError(c *gin.Context, err error) {
+	if errors.Is(err, models.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+	} else if errors.Is(err, services.ErrPermissionDenied) {
+		c.JSON(http.StatusForbidden, gin.H{"message": err.Error()})
+	} else if errors.Is(err, services.ErrDuplicateEntry) {
+		c.JSON(http.StatusConflict, gin.H{"message": "a view with this name already exists"})
+	} else if errors.Is(err, services.ErrInvalidViewFilter) || errors.Is(err, services.ErrInvalidViewProject) ||
+		errors.Is(err, services.ErrInvalidGrouping) || errors.Is(err, models.ErrInvalidQuery) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+	} else {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+	}
+}
+
+// CreateView godoc
+//	@Summary		Create view
+//	@Description	Save a named task filter with its sort and grouping. Views list the tasks of one project, or of the whole
+//	@Description	workspace when no project is given, and are private to their owner unless shared.
+//	@Tags			views
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Workspace ID"
+//	@Param			view	body		object	true	"View name, project, filter, grouping and whether it is shared"
+//	@Success		201		{object}	models.View
+//	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/workspaces/{id}/views [post]
+func (h *Handler) CreateView(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		Name      string              `json:"name" binding:"required,max=100"`
+		ProjectId uuid.UUID           `json:"projectId"`
+		Shared    bool                `json:"shared"`
+		Filter    models.TaskFilter   `json:"filter"`
+		GroupBy   models.ViewGrouping `json:"groupBy"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	view := &models.View{
+		WorkspaceId: id,
+		ProjectId:   input.ProjectId,
+		OwnerId:     uuid.MustParse(c.GetString("user_id")),
+		Name:        input.Name,
+		Shared:      input.Shared,
+		Filter:      input.Filter,
+		GroupBy:     input.GroupBy,
+	}
+
+	err = h.workspaces.CreateView(c.Request.Context(), view)
+	if err != nil {
+		viewError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, view)
+}
+
+// GetWorkspaceViews godoc
+//	@Summary		Get workspace views
+//	@Description	Get the caller's views of a workspace and the views shared with it, ordered by name
+//	@Tags			views
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Workspace ID"
+//	@Success		200	{array}		models.View
+//	@Failure		400	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/workspaces/{id}/views [get]
+func (h *Handler) GetWorkspaceViews(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	userId := uuid.MustParse(c.GetString("user_id"))
+	views, err := h.workspaces.GetWorkspaceViews(c.Request.Context(), id, userId)
+	if err != nil {
+		viewError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, views)
+}
+
+// GetView godoc
+//	@Summary		Get view
+//	@Description	Get a view owned by the caller or shared with the workspace
+//	@Tags			views
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"View ID"
+//	@Success		200	{object}	models.View
+//	@Failure		400	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/views/{id} [get]
+func (h *Handler) GetView(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	userId := uuid.MustParse(c.GetString("user_id"))
+	view, err := h.workspaces.GetView(c.Request.Context(), id, userId)
+	if err != nil {
+		viewError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// UpdateView godoc
+//	@Summary		Update view
+//	@Description	Update the fields of a view that are given. Only the owner can change a view. An empty projectId makes
+//	@Description	the view list the tasks of the whole workspace, and a filter replaces the saved one.
+//	@Tags			views
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"View ID"
+//	@Param			view	body		object	true	"Fields to update"
+//	@Success		200		{object}	models.View
+//	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/views/{id} [patch]
+func (h *Handler) UpdateView(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	var input struct {
+		Name      *string              `json:"name" binding:"omitnil,min=1,max=100"`
+		ProjectId *string              `json:"projectId"`
+		Shared    *bool                `json:"shared"`
+		Filter    *models.TaskFilter   `json:"filter"`
+		GroupBy   *models.ViewGrouping `json:"groupBy"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	userId := uuid.MustParse(c.GetString("user_id"))
+	view, err := h.workspaces.UpdateView(c.Request.Context(), id, userId, input.Name, input.ProjectId, input.Shared, input.Filter, input.GroupBy)
+	if err != nil {
+		viewError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// DeleteView godoc
+//	@Summary		Delete view
+//	@Description	Delete a view. Views can be deleted by their owner, and shared views also by workspace admins.
+//	@Tags			views
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"View ID"
+//	@Success		200	{object}	map[string]string
+//	@Failure		400	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/views/{id} [delete]
+func (h *Handler) DeleteView(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	userId := uuid.MustParse(c.GetString("user_id"))
+	role := c.MustGet("workspace_role").(models.Role)
+
+	err = h.workspaces.DeleteView(c.Request.Context(), id, userId, role)
+	if err != nil {
+		viewError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "view deleted successfully"})
+}
+
+// GetViewTasks godoc
+//	@Summary		Get view tasks
+//	@Description	Run a view's saved filter, sort and page size. Filters on the caller's tasks are resolved for the
+//	@Description	user running the view.
+//	@Tags			views
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			id		path		string	true	"View ID"
+//	@Param			limit	query		int		false	"Page size, overriding the view's"
+//	@Param			cursor	query		string	false	"Cursor of the next page"
+//	@Success		200		{object}	models.Page[models.Task]
+//	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/views/{id}/tasks [get]
+func (h *Handler) GetViewTasks(c *gin.Context) {
+	id, err := getUUIDparam(c, "id")
+	if err != nil {
+		slog.Error("failed to get uuid param", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+		return
+	}
+
+	opts, err := getListOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	userId := uuid.MustParse(c.GetString("user_id"))
+	tasks, err := h.workspaces.GetViewTasks(c.Request.Context(), id, userId, opts)
+	if err != nil {
+		viewError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
@@ -13,8 +13,9 @@ import (
 
 // CreateWorkspace godoc
 //	@Summary		Create workspace
-//	@Description	Create a new workspace
+//	@Description	Create a new workspace owned by the authenticated user
 //	@Tags			workspaces
+//	@Security		BearerAuth
 //	@Accept			json
 //	@Produce		json
 //	@Param			workspace	body		object	true	"Workspace info"
@@ -23,9 +24,8 @@ import (
 //	@Router			/workspaces [post]
 func (h *Handler) CreateWorkspace(c *gin.Context) {
 	var input struct {
-		Name        string    `json:"name" binding:"required"`
-		Description string    `json:"description"`
-		UserID      uuid.UUID `json:"userId" binding:"required,uuid"`
+		Name        string `json:"name" binding:"required"`
+		Description string `json:"description"`
 	}
 
 	err := c.ShouldBindJSON(&input)
@@ -34,10 +34,12 @@ func (h *Handler) CreateWorkspace(c *gin.Context) {
 		return
 	}
 
+	idStr, _ := c.Get("user_id")
+
 	ws := &models.Workspace{
 		Name:        input.Name,
 		Description: input.Description,
-		User:        &models.User{Id: input.UserID},
+		User:        &models.User{Id: uuid.MustParse(idStr.(string))},
 	}
 
 	err = h.workspaces.NewWorkspace(c.Request.Context(), ws)
@@ -59,6 +61,7 @@ func (h *Handler) CreateWorkspace(c *gin.Context) {
 //	@Success		200	{object}	models.Workspace
 //	@Failure		400	{object}	map[string]string
 //	@Failure		404	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
 //	@Failure		500	{object}	map[string]string
 //	@Router			/workspaces/{id} [get]
 func (h *Handler) GetWorkspace(c *gin.Context) {
@@ -119,6 +122,7 @@ func (h *Handler) GetUserWorkspaces(c *gin.Context) {
 //	@Param			workspace	body		object	true	"Workspace update info"
 //	@Success		200			{object}	models.Workspace
 //	@Failure		400			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
 //	@Failure		500			{object}	map[string]string
 //	@Router			/workspaces/{id} [patch]
 func (h *Handler) UpdateWorkspace(c *gin.Context) {
@@ -157,6 +161,7 @@ func (h *Handler) UpdateWorkspace(c *gin.Context) {
 //	@Param			id	path		string	true	"Workspace ID"
 //	@Success		200	{object}	map[string]string
 //	@Failure		400	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
 //	@Failure		500	{object}	map[string]string
 //	@Router			/workspaces/{id} [delete]
 func (h *Handler) DeleteWorkspace(c *gin.Context) {
@@ -176,21 +181,27 @@ func (h *Handler) DeleteWorkspace(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "workspace successfully deleted"})
 }
 
-// AddWorkspaceMember godoc
-//	@Summary		Add workspace member
-//	@Description	Add a member to a workspace
+// GetWorkspaceMembers godoc
+//	@Summary		Get workspace members
+//	@Description	Get a page of members of a workspace
 //	@Tags			workspaces
 //	@Security		BearerAuth
-//	@Accept			json
 //	@Produce		json
 //	@Param			id		path		string	true	"Workspace ID"
-//	@Param			member	body		object	true	"Member info"
-//	@Success		200		{object}	map[string]string
+//	@Param			role	query		string	false	"Comma separated roles"
+//	@Param			q		query		string	false	"Text to search in name and email"
+//	@Param			sort	query		string	false	"Sort field"	Enums(joined_at, name)
+//	@Param			order	query		string	false	"Sort order"	Enums(asc, desc)
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			cursor	query		string	false	"Cursor of the next page"
+//	@Success		200		{object}	models.Page[models.User]
 //	@Failure		400		{object}	map[string]string
-//	@Failure		422		{object}	map[string]string
-//	@Failure		500		{object}	map[string]string
-//	@Router			/workspaces/{id}/members [post]
-func (h *Handler) AddWorkspaceMember(c *gin.Context) {
+//	@Failure		404	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/workspaces/{id}/members [get]
+func (h *Handler) GetWorkspaceMembers(c *gin.Context) {
+
 	id, err := getUUIDparam(c, "id")
 	if err != nil {
 		slog.Error("failed to get uuid param", "error", err.Error())
@@ -198,57 +209,25 @@ func (h *Handler) AddWorkspaceMember(c *gin.Context) {
 		return
 	}
 
-	var input struct {
-		UserId uuid.UUID `json:"userId" validate:"required,uuid"`
-		Role   string    `json:"role" validate:"required"`
-	}
-
-	err = c.ShouldBindJSON(&input)
+	opts, err := getListOptions(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
 		return
 	}
 
-	err = h.workspaces.AddWorkspaceMember(c.Request.Context(), id, input.UserId, input.Role)
-	if err != nil {
-		if errors.Is(err, services.ErrFailedOperation) {
-			c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
-			return
-		}
-
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error()})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{"message": "workspace member successfully added"})
-}
-
-// GetWorkspaceMembers godoc
-//	@Summary		Get workspace members
-//	@Description	Get all members of a workspace
-//	@Tags			workspaces
-//	@Security		BearerAuth
-//	@Produce		json
-//	@Param			id	path		string	true	"Workspace ID"
-//	@Success		200	{array}		models.User
-//	@Failure		400	{object}	map[string]string
-//	@Failure		404	{object}	map[string]string
-//	@Failure		500	{object}	map[string]string
-//	@Router			/workspaces/{id}/members [get]
-func (h *Handler) GetWorkspaceMembers(c *gin.Context) {
-
-	id, err := getUUIDparam(c, "id")
-	if err != nil {
-		slog.Error("failed to get uuid param", "error", err.Error())
-		c.JSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
-		return
+	filter := models.MemberFilter{Search: c.Query("q"), ListOptions: opts}
+	for _, role := range getQueryList(c, "role") {
+		filter.Role = append(filter.Role, models.Role(role))
 	}
 
-	users, err := h.workspaces.GetWorkspaceMembers(c.Request.Context(), id)
+	users, err := h.workspaces.GetWorkspaceMembers(c.Request.Context(), id, filter)
 	if err != nil {
 		if errors.Is(err, models.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
 			return
+		} else if errors.Is(err, models.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
 		return
@@ -259,7 +238,8 @@ func (h *Handler) GetWorkspaceMembers(c *gin.Context) {
 
 // DeleteWorkspaceMember godoc
 //	@Summary		Remove workspace member
-//	@Description	Remove a member from a workspace
+//	@Description	Leave a workspace, or remove another member from it. Removing a member requires admin rights and
+//	@Description	removing an admin requires the owner. The owner cannot leave or be removed.
 //	@Tags			workspaces
 //	@Security		BearerAuth
 //	@Produce		json
@@ -267,6 +247,9 @@ func (h *Handler) GetWorkspaceMembers(c *gin.Context) {
 //	@Param			user_id	path		string	true	"Member ID"
 //	@Success		200		{object}	map[string]string
 //	@Failure		400		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
 //	@Failure		500		{object}	map[string]string
 //	@Router			/workspaces/{id}/members/{user_id} [delete]
 func (h *Handler) DeleteWorkspaceMember(c *gin.Context) {
@@ -284,9 +267,21 @@ func (h *Handler) DeleteWorkspaceMember(c *gin.Context) {
 		return
 	}
 
-	err = h.workspaces.DeleteWorkspaceMember(c.Request.Context(), id, memberId)
+	userId := uuid.MustParse(c.GetString("user_id"))
+	role := c.MustGet("workspace_role").(models.Role)
+
+	err = h.workspaces.DeleteWorkspaceMember(c.Request.Context(), id, memberId, userId, role)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		case errors.Is(err, services.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"message": err.Error()})
+		case errors.Is(err, services.ErrOwnerRemoval):
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"message": ErrServerError.Error()})
+		}
 		return
 	}
 
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/primekobie/hazel/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// memberStore serves the memberships of a single workspace held in roles and
+// records the members deleted through it.
+type memberStore struct {
+	models.WorkspaceStore
+	roles   map[uuid.UUID]models.Role
+	deleted []uuid.UUID
+}
+
+func (f *memberStore) GetMemberRole(ctx context.Context, workspaceId, userId uuid.UUID) (models.Role, error) {
+	role, ok := f.roles[userId]
+	if !ok {
+		return "", models.ErrNotFound
+	}
+	return role, nil
+}
+
+func (f *memberStore) DeleteMembership(ctx context.Context, workspaceId, userId uuid.UUID) error {
+	f.deleted = append(f.deleted, userId)
+	return nil
+}
+
+func (f *memberStore) InsertActivity(ctx context.Context, activity *models.Activity) error {
+	return nil
+}
+
+func TestDeleteWorkspaceMember(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ownerId, adminId, otherAdminId, memberId := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	roles := map[uuid.UUID]models.Role{
+		ownerId:      models.RoleOwner,
+		adminId:      models.RoleAdmin,
+		otherAdminId: models.RoleAdmin,
+		memberId:     models.RoleMember,
+	}
+
+	tests := []struct {
+		name   string
+		caller uuid.UUID
+		member uuid.UUID
+		status int
+	}{
+		{name: "admin removes owner", caller: adminId, member: ownerId, status: http.StatusConflict},
+		{name: "owner leaves", caller: ownerId, member: ownerId, status: http.StatusConflict},
+		{name: "admin removes admin", caller: adminId, member: otherAdminId, status: http.StatusForbidden},
+		{name: "member removes member", caller: memberId, member: adminId, status: http.StatusForbidden},
+		{name: "owner removes admin", caller: ownerId, member: adminId, status: http.StatusOK},
+		{name: "admin removes member", caller: adminId, member: memberId, status: http.StatusOK},
+		{name: "admin leaves", caller: adminId, member: adminId, status: http.StatusOK},
+		{name: "unknown member", caller: ownerId, member: uuid.New(), status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &memberStore{roles: roles}
+			h := NewHandler(nil, services.NewWorkspaceService(store, nil, nil, nil, nil))
+
+			router := gin.New()
+			router.DELETE("/workspaces/:id/members/:user_id", func(c *gin.Context) {
+				c.Set("user_id", tt.caller.String())
+				c.Set("workspace_role", roles[tt.caller])
+			}, h.DeleteWorkspaceMember)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, "/workspaces/"+uuid.NewString()+"/members/"+tt.member.String(), nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			if tt.status == http.StatusOK {
+				assert.Equal(t, []uuid.UUID{tt.member}, store.deleted)
+			} else {
+				assert.Empty(t, store.deleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// HTTPTransport delivers messages by posting them as JSON to a mail
+// provider's HTTP API.
+type HTTPTransport struct {
+	host   string
+	token  string
+	client *http.Client
+}
+
+func NewHTTPTransport(host, token string, timeout time.Duration) *HTTPTransport {
+	return &HTTPTransport{
+		host:   host,
+		token:  token,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Send implements Transport.
+func (t *HTTPTransport) Send(ctx context.Context, msg *Message) error {
+	msgJson, err := json.Marshal(msg)
+	if err != nil {
+		slog.Error("error marshalling mail message", "error", err)
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", t.host, bytes.NewBuffer(msgJson))
+	if err != nil {
+		slog.Error("error creating request", "error", err)
+		return err
+	}
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", t.token))
+	req.Header.Add("Content-Type", "application/json")
+
+	res, err := t.client.Do(req)
+	if err != nil {
+		slog.Error("error sending request", "error", err)
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			slog.Error("error reading body", "error", err)
+			return err
+		}
+
+		slog.Error("mail provider rejected message", slog.String("status", res.Status), slog.String("body", string(body)))
+		return errors.New("error sending email: " + res.Status)
+	}
+
+	return nil
+}
@@ -2,32 +2,62 @@ package mail
 
 import (
 	"bytes"
+	"context"
 	"embed"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"html/template"
-	"io"
 	"log/slog"
-	"net/http"
 	"time"
 )
 
 //go:embed template
 var mailFS embed.FS
 
+// Names of the transports a Mailer can deliver messages with.
+const (
+	TransportHTTP = "http"
+	TransportSMTP = "smtp"
+	TransportSink = "sink"
+)
+
 type Mailer struct {
-	cfg *Config
+	cfg       *Config
+	transport Transport
 }
 
+// Config configures a Mailer. Transport selects how messages are delivered:
+// through the mail provider's HTTP API at Host, authenticated with Token, or
+// through the SMTP server described by SMTP, or into a development Sink that
+// also writes messages to SinkDir when it is set. Timeout bounds each delivery.
 type Config struct {
+	Transport   string
 	Host        string
 	Token       string
+	SMTP        SMTPConfig
+	SinkDir     string
 	Timeout     time.Duration
 	SenderName  string
 	SenderEmail string
 }
 
+// Transport delivers composed messages.
+type Transport interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// NewTransport returns the transport selected by config.
+func NewTransport(config *Config) (Transport, error) {
+	switch config.Transport {
+	case "", TransportHTTP:
+		return NewHTTPTransport(config.Host, config.Token, config.Timeout), nil
+	case TransportSMTP:
+		return NewSMTPTransport(config.SMTP, config.Timeout)
+	case TransportSink:
+		return NewSink(config.SinkDir, DefaultSinkSize)
+	}
+	return nil, fmt.Errorf("unknown mail transport %q", config.Transport)
+}
+
 type Address struct {
 	Name  string `json:"name"`
 	Email string `json:"email"`
@@ -46,14 +76,17 @@ type Data struct {
 	Code    any
 }
 
-func NewMailer(config *Config) *Mailer {
+func NewMailer(config *Config, transport Transport) *Mailer {
 	mailer := &Mailer{
-		cfg: config,
+		cfg:       config,
+		transport: transport,
 	}
 	return mailer
 }
 
-func (m *Mailer) generateMessage(recipient []Address, templateFile string, data any) (*Message, error) {
+// Compose renders a message to recipients from one of the embedded
+// templates, which defines its subject, text and html parts.
+func (m *Mailer) Compose(recipient []Address, templateFile string, data any) (*Message, error) {
 	tmpl, err := template.ParseFS(mailFS, fmt.Sprintf("template/%s", templateFile))
 	if err != nil {
 		slog.Error("error parsing FS", "error", err)
@@ -92,45 +125,7 @@ func (m *Mailer) generateMessage(recipient []Address, templateFile string, data
 	return &msg, nil
 }
 
-func (m *Mailer) Send(recipients []Address, templateFile string, data any) error {
-	msg, err := m.generateMessage(recipients, templateFile, data)
-	if err != nil {
-		return err
-	}
-
-	msgJson, err := json.Marshal(msg)
-	if err != nil {
-		slog.Error("error marshalling mail message", "error", err)
-		return err
-	}
-
-	req, err := http.NewRequest("POST", m.cfg.Host, bytes.NewBuffer(msgJson))
-	if err != nil {
-		slog.Error("error creating request", "error", err)
-		return err
-	}
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", m.cfg.Token))
-	req.Header.Add("Content-Type", "application/json")
-
-	client := &http.Client{Timeout: m.cfg.Timeout}
-	res, err := client.Do(req)
-	if err != nil {
-		slog.Error("error sending request", "error", err)
-		return err
-	}
-
-	defer res.Body.Close()
-
-	if res.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(res.Body)
-		if err != nil {
-			slog.Error("error reading body", "error", err)
-			return err
-		}
-
-		slog.Error("error executing template", slog.String("status", res.Status), slog.Any("body", body))
-		return errors.New("error sending email: " + res.Status)
-	}
-
-	return nil
+// Deliver sends a composed message with the mailer's transport.
+func (m *Mailer) Deliver(ctx context.Context, msg *Message) error {
+	return m.transport.Send(ctx, msg)
 }
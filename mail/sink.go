@@ -0,0 +1,98 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultSinkSize is the number of recent messages a Sink keeps in memory.
+const DefaultSinkSize = 50
+
+// SinkMessage is a message caught by a Sink.
+type SinkMessage struct {
+	Id     int       `json:"id"`
+	SentAt time.Time `json:"sentAt"`
+	Message
+}
+
+// Sink is a development transport that keeps the most recent messages in
+// memory instead of delivering them, so they can be read back through the
+// dev mail endpoints. When dir is set every message is also written there as
+// an .eml file that any mail client can open.
+type Sink struct {
+	mu       sync.Mutex
+	dir      string
+	size     int
+	nextId   int
+	messages []SinkMessage
+}
+
+func NewSink(dir string, size int) (*Sink, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	if size <= 0 {
+		size = DefaultSinkSize
+	}
+	return &Sink{dir: dir, size: size, nextId: 1}, nil
+}
+
+// Send implements Transport.
+func (s *Sink) Send(ctx context.Context, msg *Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	caught := SinkMessage{Id: s.nextId, SentAt: time.Now().UTC(), Message: *msg}
+
+	if s.dir != "" {
+		data, err := encodeMessage(msg, caught.SentAt)
+		if err != nil {
+			return err
+		}
+		name := fmt.Sprintf("%s-%d.eml", caught.SentAt.Format("20060102T150405"), caught.Id)
+		if err := os.WriteFile(filepath.Join(s.dir, name), data, 0o644); err != nil {
+			slog.Error("error writing mail to sink", "error", err)
+			return err
+		}
+	}
+
+	s.nextId++
+	s.messages = append(s.messages, caught)
+	if len(s.messages) > s.size {
+		s.messages = slices.Delete(s.messages, 0, len(s.messages)-s.size)
+	}
+
+	slog.Info("mail caught by sink", "id", caught.Id, "subject", msg.Subject)
+	return nil
+}
+
+// Messages returns the caught messages, newest first.
+func (s *Sink) Messages() []SinkMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages := slices.Clone(s.messages)
+	slices.Reverse(messages)
+	return messages
+}
+
+// Message returns the caught message with the given id, if it is still kept.
+func (s *Sink) Message(id int) (SinkMessage, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, msg := range s.messages {
+		if msg.Id == id {
+			return msg, true
+		}
+	}
+	return SinkMessage{}, false
+}
@@ -0,0 +1,44 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSink(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := NewSink(dir, 2)
+	require.NoError(t, err)
+
+	for _, subject := range []string{"first", "second", "third"} {
+		msg := *testMessage
+		msg.Subject = subject
+		require.NoError(t, sink.Send(context.Background(), &msg))
+	}
+
+	messages := sink.Messages()
+	require.Len(t, messages, 2)
+	assert.Equal(t, "third", messages[0].Subject)
+	assert.Equal(t, "second", messages[1].Subject)
+
+	_, ok := sink.Message(1)
+	assert.False(t, ok, "the oldest message is dropped")
+
+	msg, ok := sink.Message(2)
+	require.True(t, ok)
+	assert.Equal(t, "second", msg.Subject)
+	assert.Equal(t, testMessage.HTML, msg.HTML)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Content-Type: multipart/alternative")
+}
@@ -0,0 +1,199 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTPConfig describes an SMTP relay. StartTLS requires the connection to be
+// upgraded to TLS before anything is sent, and should only be turned off for
+// local mail catchers. Messages are sent without authentication when
+// Username is empty.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	StartTLS bool
+}
+
+// SMTPTransport delivers messages to an SMTP relay as multipart/alternative
+// emails with a text and an HTML part.
+type SMTPTransport struct {
+	cfg     SMTPConfig
+	timeout time.Duration
+}
+
+func NewSMTPTransport(config SMTPConfig, timeout time.Duration) (*SMTPTransport, error) {
+	if config.Host == "" {
+		return nil, errors.New("smtp host is required")
+	}
+	if config.Port == 0 {
+		config.Port = 587
+	}
+	return &SMTPTransport{cfg: config, timeout: timeout}, nil
+}
+
+// Send implements Transport.
+func (t *SMTPTransport) Send(ctx context.Context, msg *Message) error {
+	if len(msg.To) == 0 {
+		return errors.New("message has no recipients")
+	}
+
+	body, err := encodeMessage(msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if t.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+		defer cancel()
+	}
+
+	addr := net.JoinHostPort(t.cfg.Host, strconv.Itoa(t.cfg.Port))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	// net/smtp knows nothing of contexts, so end the session by closing the
+	// connection when ctx is done
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, t.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp: %w", err)
+	}
+	defer client.Close()
+
+	if t.cfg.StartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("smtp: server does not support STARTTLS")
+		}
+		if err := client.StartTLS(&tls.Config{ServerName: t.cfg.Host}); err != nil {
+			return fmt.Errorf("smtp: %w", err)
+		}
+	}
+
+	if t.cfg.Username != "" {
+		auth := smtp.PlainAuth("", t.cfg.Username, t.cfg.Password, t.cfg.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("smtp: %w", err)
+		}
+	}
+
+	if err := client.Mail(msg.From.Email); err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	for _, to := range msg.To {
+		if err := client.Rcpt(to.Email); err != nil {
+			return fmt.Errorf("smtp: %w", err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// formatAddress formats a as an RFC 5322 address, encoding non-ASCII names.
+func formatAddress(a Address) string {
+	return (&netmail.Address{Name: a.Name, Address: a.Email}).String()
+}
+
+// messageID returns a unique Message-ID in the sender's domain.
+func messageID(from string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(from, "@"); at >= 0 {
+		domain = from[at+1:]
+	}
+
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), domain)
+}
+
+// encodeMessage renders msg as a MIME email with the text and HTML bodies as
+// quoted-printable alternatives.
+func encodeMessage(msg *Message, date time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	parts := multipart.NewWriter(&buf)
+
+	to := make([]string, len(msg.To))
+	for i, a := range msg.To {
+		to[i] = formatAddress(a)
+	}
+
+	headers := []struct{ key, value string }{
+		{"From", formatAddress(msg.From)},
+		{"To", strings.Join(to, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", msg.Subject)},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"Message-ID", messageID(msg.From.Email)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", parts.Boundary())},
+	}
+
+	var head bytes.Buffer
+	for _, h := range headers {
+		fmt.Fprintf(&head, "%s: %s\r\n", h.key, h.value)
+	}
+	head.WriteString("\r\n")
+
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	return append(head.Bytes(), buf.Bytes()...), nil
+}
@@ -0,0 +1,161 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	netmail "net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMessage = &Message{
+	From:    Address{Name: "hazel", Email: "noreply@hazel.local"},
+	To:      []Address{{Name: "Zoë", Email: "zoe@example.com"}},
+	Subject: "Grace assigned you to “Fix login”",
+	Text:    "Hi Zoë,\n\nGrace assigned you to “Fix login”.\n",
+	HTML:    "<p>Hi Zoë,</p>",
+}
+
+func TestEncodeMessage(t *testing.T) {
+	data, err := encodeMessage(testMessage, time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	msg, err := netmail.ReadMessage(strings.NewReader(string(data)))
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, testMessage.Subject, subject)
+
+	to, err := msg.Header.AddressList("To")
+	require.NoError(t, err)
+	assert.Equal(t, []*netmail.Address{{Name: "Zoë", Address: "zoe@example.com"}}, to)
+	assert.Equal(t, "Fri, 14 Mar 2025 09:30:00 +0000", msg.Header.Get("Date"))
+	assert.True(t, strings.HasSuffix(msg.Header.Get("Message-ID"), "@hazel.local>"))
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	for _, want := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", strings.ReplaceAll(testMessage.Text, "\n", "\r\n")},
+		{"text/html; charset=utf-8", testMessage.HTML},
+	} {
+		part, err := parts.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, want.contentType, part.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		assert.Equal(t, want.body, string(body))
+	}
+}
+
+// serveSMTP answers a single SMTP session on l without TLS, returning the
+// commands it received and the message data through the channels.
+func serveSMTP(t *testing.T, l net.Listener) (<-chan []string, <-chan string) {
+	commands, data := make(chan []string, 1), make(chan string, 1)
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(s string) { io.WriteString(conn, s+"\r\n") }
+		received := []string{}
+
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				commands <- received
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			received = append(received, line)
+
+			switch verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); verb {
+			case "EHLO":
+				reply("250-localhost")
+				reply("250 AUTH PLAIN")
+			case "AUTH":
+				reply("235 2.7.0 Authentication successful")
+			case "DATA":
+				reply("354 go ahead")
+				var body strings.Builder
+				for {
+					l, _ := r.ReadString('\n')
+					if l == ".\r\n" {
+						break
+					}
+					body.WriteString(l)
+				}
+				data <- body.String()
+				reply("250 OK")
+			case "QUIT":
+				reply("221 bye")
+				commands <- received
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	return commands, data
+}
+
+func TestSMTPTransport_Send(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	commands, data := serveSMTP(t, l)
+
+	port := l.Addr().(*net.TCPAddr).Port
+	transport, err := NewSMTPTransport(SMTPConfig{Host: "127.0.0.1", Port: port, Username: "hazel", Password: "secret"}, 5*time.Second)
+	require.NoError(t, err)
+
+	err = transport.Send(context.Background(), testMessage)
+	require.NoError(t, err)
+
+	received := <-commands
+	auth := base64.StdEncoding.EncodeToString([]byte("\x00hazel\x00secret"))
+	assert.Equal(t, []string{
+		"EHLO localhost",
+		"AUTH PLAIN " + auth,
+		"MAIL FROM:<noreply@hazel.local>",
+		"RCPT TO:<zoe@example.com>",
+		"DATA",
+		"QUIT",
+	}, received)
+	assert.Contains(t, <-data, "Content-Type: multipart/alternative")
+}
+
+func TestSMTPTransport_RequiresStartTLS(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	serveSMTP(t, l)
+
+	port := l.Addr().(*net.TCPAddr).Port
+	transport, err := NewSMTPTransport(SMTPConfig{Host: "127.0.0.1", Port: port, StartTLS: true}, 5*time.Second)
+	require.NoError(t, err)
+
+	err = transport.Send(context.Background(), testMessage)
+	assert.ErrorContains(t, err, "STARTTLS")
+}
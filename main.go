@@ -8,10 +8,13 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/primekobie/hazel/events"
 	"github.com/primekobie/hazel/handlers"
 	"github.com/primekobie/hazel/mail"
+	"github.com/primekobie/hazel/middlewares"
 	"github.com/primekobie/hazel/postgres"
 	"github.com/primekobie/hazel/services"
+	"github.com/primekobie/hazel/storage"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 )
@@ -41,13 +44,47 @@ func main() {
 		panic(err)
 	}
 
-	mailer := mail.NewMailer(cfg.MailConfig)
-	userService := services.NewUserService(postgres.NewUserStore(db), mailer)
-	workspaceService := services.NewWorkspaceService(postgres.NewWorkspaceStore(db))
+	files, err := storage.NewLocal(cfg.StorageDir)
+	if err != nil {
+		panic(err)
+	}
+
+	transport, err := mail.NewTransport(cfg.MailConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	mailer := mail.NewMailer(cfg.MailConfig, transport)
+	outbox := postgres.NewOutboxStore(db)
+	userService := services.NewUserService(postgres.NewUserStore(db), mailer, outbox, files)
+	workspaceStore := postgres.NewWorkspaceStore(db)
+	bus := events.NewBus(events.DefaultReplaySize)
+	workspaceService := services.NewWorkspaceService(workspaceStore, mailer, outbox, bus, files)
 
 	handler := handlers.NewHandler(userService, workspaceService)
+	authorizer := middlewares.NewAuthorizer(workspaceStore)
+
+	app := newApplication(handler, authorizer, cfg.ServerAddress)
+	if sink, ok := transport.(*mail.Sink); ok && cfg.Dev {
+		app.devMail = handlers.NewDevMail(sink)
+	}
+	// end open event streams so they don't hold up a graceful shutdown
+	app.server.RegisterOnShutdown(bus.Close)
 
-	app := newApplication(handler, cfg.ServerAddress)
+	// due soon reminders and notification digests
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go workspaceService.RunNotificationJobs(jobsCtx, time.Hour)
+
+	// deliver queued emails
+	mailWorker := services.NewMailWorker(outbox, mailer, 5*time.Second)
+	mailCtx, stopMail := context.WithCancel(context.Background())
+	defer stopMail()
+	mailDone := make(chan struct{})
+	go func() {
+		mailWorker.Run(mailCtx)
+		close(mailDone)
+	}()
 
 	// Graceful shutdown setup
 	stop := make(chan os.Signal, 1)
@@ -66,10 +103,17 @@ func main() {
 		}
 	case sig := <-stop:
 		slog.Info("Shutting down server", "signal", sig)
+		stopJobs()
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		if err := app.shutdown(ctx); err != nil {
 			slog.Error("Graceful shutdown failed", "error", err)
 		}
+
+		// no more emails are queued once requests have finished, so send
+		// what is due before exiting
+		stopMail()
+		<-mailDone
+		mailWorker.Drain(ctx)
 	}
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/primekobie/hazel/auth"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 func Authentication() gin.HandlerFunc {
@@ -23,7 +24,14 @@ func Authentication() gin.HandlerFunc {
 			return
 		}
 
+		userId, err := uuid.Parse(claims.Subject)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Invalid token"})
+			return
+		}
+
 		c.Set("user_id", claims.Subject)
+		c.Request = c.Request.WithContext(auth.WithUserID(c.Request.Context(), userId))
 
 		c.Next()
 	}
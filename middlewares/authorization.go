@@ -1 +1,131 @@
 package middlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrForbidden = errors.New("you do not have permission to perform this action")
+)
+
+// IDSource extracts the id of the resource a request operates on.
+type IDSource func(c *gin.Context) (string, error)
+
+// Param reads the resource id from the named path parameter.
+func Param(key string) IDSource {
+	return func(c *gin.Context) (string, error) {
+		return c.Param(key), nil
+	}
+}
+
+// Body reads the resource id from a top-level field of the JSON request body.
+// The body is restored afterwards so handlers can bind it as usual.
+func Body(field string) IDSource {
+	return func(c *gin.Context) (string, error) {
+		data, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			return "", err
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(data))
+
+		var body map[string]any
+		if err := json.Unmarshal(data, &body); err != nil {
+			return "", err
+		}
+
+		value, ok := body[field].(string)
+		if !ok {
+			return "", fmt.Errorf("%s is required", field)
+		}
+
+		return value, nil
+	}
+}
+
+// Authorizer enforces workspace role based access control.
+type Authorizer struct {
+	store models.WorkspaceStore
+}
+
+func NewAuthorizer(store models.WorkspaceStore) *Authorizer {
+	return &Authorizer{
+		store: store,
+	}
+}
+
+// Require aborts the request unless the authenticated user holds at least role
+// in the workspace that owns the resource identified by source. On success the
+// workspace id and the caller's role are stored in the context as
+// "workspace_id" and "workspace_role".
+func (a *Authorizer) Require(role models.Role, resource models.Resource, source IDSource) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userId, err := uuid.Parse(c.GetString("user_id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Invalid token"})
+			return
+		}
+
+		raw, err := source(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": "invalid id format"})
+			return
+		}
+
+		ctx := c.Request.Context()
+		workspaceId, err := a.store.GetResourceWorkspace(ctx, resource, id)
+		if err != nil {
+			if errors.Is(err, models.ErrNotFound) {
+				c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"message": fmt.Sprintf("%s not found", resource)})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "the server could not process your request"})
+			return
+		}
+
+		memberRole, err := a.store.GetMemberRole(ctx, workspaceId, userId)
+		if err != nil && !errors.Is(err, models.ErrNotFound) {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "the server could not process your request"})
+			return
+		}
+
+		if !memberRole.Includes(role) {
+			slog.Info("denied workspace access", "user_id", userId, "workspace_id", workspaceId, "role", memberRole, "required", role)
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": ErrForbidden.Error()})
+			return
+		}
+
+		c.Set("workspace_id", workspaceId)
+		c.Set("workspace_role", memberRole)
+
+		c.Next()
+	}
+}
+
+// Self aborts the request unless the path parameter key matches the
+// authenticated user's id.
+func Self(key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Param(key) != c.GetString("user_id") {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": ErrForbidden.Error()})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,102 @@
+package middlewares_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/primekobie/hazel/middlewares"
+	"github.com/primekobie/hazel/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStore struct {
+	models.WorkspaceStore
+	workspaces map[uuid.UUID]uuid.UUID
+	roles      map[uuid.UUID]models.Role
+}
+
+func (f *fakeStore) GetResourceWorkspace(ctx context.Context, resource models.Resource, id uuid.UUID) (uuid.UUID, error) {
+	ws, ok := f.workspaces[id]
+	if !ok {
+		return uuid.Nil, models.ErrNotFound
+	}
+	return ws, nil
+}
+
+func (f *fakeStore) GetMemberRole(ctx context.Context, workspaceId, userId uuid.UUID) (models.Role, error) {
+	role, ok := f.roles[userId]
+	if !ok {
+		return "", models.ErrNotFound
+	}
+	return role, nil
+}
+
+func TestAuthorizer_Require(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	workspaceId := uuid.New()
+	projectId := uuid.New()
+	admin, viewer, outsider := uuid.New(), uuid.New(), uuid.New()
+
+	store := &fakeStore{
+		workspaces: map[uuid.UUID]uuid.UUID{projectId: workspaceId},
+		roles: map[uuid.UUID]models.Role{
+			admin:  models.RoleAdmin,
+			viewer: models.RoleViewer,
+		},
+	}
+	authz := middlewares.NewAuthorizer(store)
+
+	tests := []struct {
+		name     string
+		user     uuid.UUID
+		path     string
+		body     string
+		wantCode int
+	}{
+		{name: "admin allowed", user: admin, path: "/projects/" + projectId.String(), wantCode: http.StatusOK},
+		{name: "viewer denied", user: viewer, path: "/projects/" + projectId.String(), wantCode: http.StatusForbidden},
+		{name: "non member denied", user: outsider, path: "/projects/" + projectId.String(), wantCode: http.StatusForbidden},
+		{name: "unknown project", user: admin, path: "/projects/" + uuid.NewString(), wantCode: http.StatusNotFound},
+		{name: "invalid id", user: admin, path: "/projects/abc", wantCode: http.StatusBadRequest},
+		{name: "id from body", user: admin, path: "/tasks", body: `{"projectId":"` + projectId.String() + `"}`, wantCode: http.StatusOK},
+		{name: "missing body field", user: admin, path: "/tasks", body: `{}`, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("user_id", tt.user.String())
+			})
+			ok := func(c *gin.Context) {
+				assert.Equal(t, workspaceId, c.MustGet("workspace_id"))
+				c.Status(http.StatusOK)
+			}
+			router.PATCH("/projects/:id", authz.Require(models.RoleMember, models.ResourceProject, middlewares.Param("id")), ok)
+			router.POST("/tasks", authz.Require(models.RoleMember, models.ResourceProject, middlewares.Body("projectId")), ok)
+
+			method := http.MethodPatch
+			if tt.body != "" {
+				method = http.MethodPost
+			}
+			req := httptest.NewRequest(method, tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
+
+func TestRole_Includes(t *testing.T) {
+	assert.True(t, models.RoleOwner.Includes(models.RoleAdmin))
+	assert.True(t, models.RoleMember.Includes(models.RoleMember))
+	assert.False(t, models.RoleViewer.Includes(models.RoleMember))
+	assert.False(t, models.Role("").Includes(models.RoleViewer))
+}
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TYPE scope_type ADD VALUE IF NOT EXISTS 'password_reset';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM user_tokens WHERE scope = 'password_reset';
+
+ALTER TYPE scope_type RENAME TO scope_type_old;
+CREATE TYPE scope_type AS ENUM ('authentication', 'verification');
+ALTER TABLE user_tokens ALTER COLUMN scope TYPE scope_type USING scope::text::scope_type;
+DROP TYPE scope_type_old;
+-- +goose StatementEnd
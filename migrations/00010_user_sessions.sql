@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_tokens
+    ADD COLUMN session_id uuid,
+    ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT now(),
+    ADD COLUMN user_agent TEXT,
+    ADD COLUMN ip_address TEXT;
+
+UPDATE user_tokens SET session_id = gen_random_uuid() WHERE scope = 'authentication';
+
+CREATE INDEX idx_user_tokens_session ON user_tokens (session_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_user_tokens_session;
+
+ALTER TABLE user_tokens
+    DROP COLUMN IF EXISTS session_id,
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS user_agent,
+    DROP COLUMN IF EXISTS ip_address;
+-- +goose StatementEnd
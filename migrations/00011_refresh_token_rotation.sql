@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_tokens ADD COLUMN rotated_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_tokens DROP COLUMN IF EXISTS rotated_at;
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS task_comments(
+    id uuid NOT NULL,
+    task_id uuid NOT NULL,
+    author_id uuid NOT NULL,
+    parent_id uuid,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT now() NOT NULL,
+    last_modified TIMESTAMP DEFAULT now() NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (author_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (parent_id) REFERENCES task_comments(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_task_comments_task ON task_comments (task_id, created_at);
+
+CREATE TABLE IF NOT EXISTS comment_mentions(
+    comment_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    PRIMARY KEY (comment_id, user_id),
+    FOREIGN KEY (comment_id) REFERENCES task_comments(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS comment_edits(
+    comment_id uuid NOT NULL,
+    previous_body TEXT NOT NULL,
+    edited_at TIMESTAMP DEFAULT now() NOT NULL,
+    FOREIGN KEY (comment_id) REFERENCES task_comments(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_comment_edits_comment ON comment_edits (comment_id, edited_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS comment_edits;
+DROP TABLE IF EXISTS comment_mentions;
+DROP TABLE IF EXISTS task_comments;
+-- +goose StatementEnd
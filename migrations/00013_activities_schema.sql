@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS activities(
+    id uuid NOT NULL,
+    workspace_id uuid NOT NULL,
+    actor_id uuid,
+    entity_type TEXT NOT NULL,
+    entity_id uuid NOT NULL,
+    action TEXT NOT NULL,
+    changes JSONB,
+    created_at TIMESTAMP DEFAULT now() NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_activities_workspace ON activities (workspace_id, created_at);
+
+-- the log is append-only; rows only disappear along with their workspace
+CREATE OR REPLACE FUNCTION reject_activity_update() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'activities are append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER activities_append_only
+    BEFORE UPDATE ON activities
+    FOR EACH ROW EXECUTE FUNCTION reject_activity_update();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS activities;
+DROP FUNCTION IF EXISTS reject_activity_update();
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS workspace_invitations(
+    id uuid NOT NULL,
+    workspace_id uuid NOT NULL,
+    email TEXT NOT NULL,
+    role TEXT NOT NULL,
+    invited_by uuid,
+    status TEXT DEFAULT 'pending' NOT NULL,
+    created_at TIMESTAMP DEFAULT now() NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    responded_at TIMESTAMP,
+    PRIMARY KEY (id),
+    FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE,
+    FOREIGN KEY (invited_by) REFERENCES users(id) ON DELETE SET NULL,
+    CHECK (status IN ('pending', 'accepted', 'declined', 'revoked'))
+);
+
+CREATE INDEX idx_workspace_invitations_email ON workspace_invitations (lower(email)) WHERE status = 'pending';
+CREATE INDEX idx_workspace_invitations_workspace ON workspace_invitations (workspace_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS workspace_invitations;
+-- +goose StatementEnd
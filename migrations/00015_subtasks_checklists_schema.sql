@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS parent_id uuid REFERENCES tasks(id) ON DELETE CASCADE;
+ALTER TABLE tasks ADD CONSTRAINT tasks_parent_not_self CHECK (parent_id <> id);
+
+CREATE INDEX idx_tasks_parent ON tasks (parent_id);
+
+CREATE TABLE IF NOT EXISTS checklist_items(
+    id uuid NOT NULL,
+    task_id uuid NOT NULL,
+    title TEXT NOT NULL,
+    done BOOLEAN DEFAULT false NOT NULL,
+    position INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT now() NOT NULL,
+    last_modified TIMESTAMP DEFAULT now() NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_checklist_items_task ON checklist_items (task_id, position);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS checklist_items;
+DROP INDEX IF EXISTS idx_tasks_parent;
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_parent_not_self;
+ALTER TABLE tasks DROP COLUMN IF EXISTS parent_id;
+-- +goose StatementEnd
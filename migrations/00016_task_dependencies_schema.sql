@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS task_dependencies(
+    task_id uuid NOT NULL,
+    blocker_id uuid NOT NULL,
+    created_at TIMESTAMP DEFAULT now() NOT NULL,
+    PRIMARY KEY (task_id, blocker_id),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (blocker_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    CHECK (task_id <> blocker_id)
+);
+
+CREATE INDEX idx_task_dependencies_blocker ON task_dependencies (blocker_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS task_dependencies;
+-- +goose StatementEnd
@@ -0,0 +1,81 @@
+-- +goose Up
+-- +goose StatementBegin
+DO
+$$
+    BEGIN
+        CREATE TYPE status_category AS ENUM ('open', 'in_progress', 'done');
+    EXCEPTION
+        WHEN duplicate_object THEN null;
+    END
+$$;
+
+CREATE TABLE IF NOT EXISTS project_statuses(
+    id uuid NOT NULL,
+    project_id uuid NOT NULL,
+    key TEXT NOT NULL,
+    name TEXT NOT NULL,
+    category status_category NOT NULL,
+    position INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT now() NOT NULL,
+    last_modified TIMESTAMP DEFAULT now() NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (project_id, key),
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS status_transitions(
+    project_id uuid NOT NULL,
+    from_status TEXT NOT NULL,
+    to_status TEXT NOT NULL,
+    PRIMARY KEY (project_id, from_status, to_status),
+    FOREIGN KEY (project_id, from_status) REFERENCES project_statuses(project_id, key) ON DELETE CASCADE,
+    FOREIGN KEY (project_id, to_status) REFERENCES project_statuses(project_id, key) ON DELETE CASCADE
+);
+
+-- every existing project gets the statuses of the old enum
+INSERT INTO project_statuses(id, project_id, key, name, category, position)
+SELECT gen_random_uuid(), p.id, s.key, s.name, s.category::status_category, s.position
+FROM projects AS p
+CROSS JOIN (VALUES
+    ('todo', 'To do', 'open', 0),
+    ('started', 'In progress', 'in_progress', 1),
+    ('complete', 'Complete', 'done', 2)
+) AS s(key, name, category, position);
+
+ALTER TABLE tasks ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE tasks ALTER COLUMN status TYPE TEXT USING status::text;
+ALTER TABLE tasks ADD CONSTRAINT tasks_project_status_fkey
+    FOREIGN KEY (project_id, status) REFERENCES project_statuses(project_id, key);
+
+DROP TYPE IF EXISTS task_status;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DO
+$$
+    BEGIN
+        CREATE TYPE task_status AS ENUM ('todo', 'started', 'complete');
+    EXCEPTION
+        WHEN duplicate_object THEN null;
+    END
+$$;
+
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_project_status_fkey;
+
+-- custom statuses fall back to the old status of their category
+UPDATE tasks AS t SET status = CASE ps.category
+    WHEN 'open' THEN 'todo'
+    WHEN 'in_progress' THEN 'started'
+    ELSE 'complete'
+END
+FROM project_statuses AS ps
+WHERE ps.project_id = t.project_id AND ps.key = t.status;
+
+ALTER TABLE tasks ALTER COLUMN status TYPE task_status USING status::task_status;
+ALTER TABLE tasks ALTER COLUMN status SET DEFAULT 'todo';
+
+DROP TABLE IF EXISTS status_transitions;
+DROP TABLE IF EXISTS project_statuses;
+DROP TYPE IF EXISTS status_category;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS rank TEXT COLLATE "C";
+
+-- existing tasks keep their creation order within each status column; decimal
+-- digits are valid rank digits
+UPDATE tasks AS t SET rank = r.rank
+FROM (
+    SELECT id, lpad((row_number() OVER (PARTITION BY project_id, status ORDER BY created_at, id) * 1000)::text, 10, '0') AS rank
+    FROM tasks
+) AS r
+WHERE t.id = r.id;
+
+ALTER TABLE tasks ALTER COLUMN rank SET NOT NULL;
+
+CREATE INDEX idx_tasks_column_rank ON tasks (project_id, status, rank);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tasks_column_rank;
+ALTER TABLE tasks DROP COLUMN IF EXISTS rank;
+-- +goose StatementEnd
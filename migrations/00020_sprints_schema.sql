@@ -0,0 +1,55 @@
+-- +goose Up
+-- +goose StatementBegin
+DO
+$$
+    BEGIN
+        CREATE TYPE sprint_state AS ENUM ('planned', 'active', 'closed');
+    EXCEPTION
+        WHEN duplicate_object THEN null;
+    END
+$$;
+
+CREATE TABLE IF NOT EXISTS sprints(
+    id uuid NOT NULL,
+    project_id uuid NOT NULL,
+    name TEXT NOT NULL,
+    goal TEXT NOT NULL DEFAULT '',
+    start_date DATE,
+    end_date DATE,
+    state sprint_state DEFAULT 'planned' NOT NULL,
+    started_at TIMESTAMP,
+    closed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT now() NOT NULL,
+    last_modified TIMESTAMP DEFAULT now() NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+);
+
+-- a project runs one sprint at a time
+CREATE UNIQUE INDEX idx_sprints_active ON sprints (project_id) WHERE state = 'active';
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS sprint_id uuid REFERENCES sprints(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_tasks_sprint ON tasks (sprint_id);
+
+-- committed marks the tasks a sprint held when it started; completed is set
+-- for the tasks it held when it closed
+CREATE TABLE IF NOT EXISTS sprint_tasks(
+    sprint_id uuid NOT NULL,
+    task_id uuid NOT NULL,
+    committed BOOLEAN NOT NULL,
+    completed BOOLEAN,
+    PRIMARY KEY (sprint_id, task_id),
+    FOREIGN KEY (sprint_id) REFERENCES sprints(id) ON DELETE CASCADE,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS sprint_tasks;
+DROP INDEX IF EXISTS idx_tasks_sprint;
+ALTER TABLE tasks DROP COLUMN IF EXISTS sprint_id;
+DROP TABLE IF EXISTS sprints;
+DROP TYPE IF EXISTS sprint_state;
+-- +goose StatementEnd
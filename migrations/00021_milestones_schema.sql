@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS milestones(
+    id uuid NOT NULL,
+    project_id uuid NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    due DATE,
+    created_at TIMESTAMP DEFAULT now() NOT NULL,
+    last_modified TIMESTAMP DEFAULT now() NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+);
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS milestone_id uuid REFERENCES milestones(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_tasks_milestone ON tasks (milestone_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tasks_milestone;
+ALTER TABLE tasks DROP COLUMN IF EXISTS milestone_id;
+DROP TABLE IF EXISTS milestones;
+-- +goose StatementEnd
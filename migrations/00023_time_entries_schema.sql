@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS time_entries(
+    id uuid NOT NULL,
+    task_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP NOT NULL,
+    ended_at TIMESTAMP,
+    duration BIGINT NOT NULL DEFAULT 0 CHECK (duration >= 0),
+    created_at TIMESTAMP DEFAULT now() NOT NULL,
+    last_modified TIMESTAMP DEFAULT now() NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_time_entries_task ON time_entries (task_id);
+CREATE INDEX idx_time_entries_user_started ON time_entries (user_id, started_at);
+
+-- a user can only run one timer at a time
+CREATE UNIQUE INDEX idx_time_entries_running ON time_entries (user_id) WHERE ended_at IS NULL;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS estimate BIGINT NOT NULL DEFAULT 0 CHECK (estimate >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tasks DROP COLUMN IF EXISTS estimate;
+DROP TABLE IF EXISTS time_entries;
+-- +goose StatementEnd
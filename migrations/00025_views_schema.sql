@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS views(
+    id uuid NOT NULL,
+    workspace_id uuid NOT NULL,
+    project_id uuid,
+    owner_id uuid NOT NULL,
+    name TEXT NOT NULL,
+    shared BOOLEAN NOT NULL DEFAULT false,
+    filter JSONB NOT NULL DEFAULT '{}',
+    group_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT now() NOT NULL,
+    last_modified TIMESTAMP DEFAULT now() NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+    FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_views_owner_name ON views (workspace_id, owner_id, lower(name));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS views;
+-- +goose StatementEnd
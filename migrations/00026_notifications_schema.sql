@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS notifications(
+    id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    type TEXT NOT NULL,
+    workspace_id uuid NOT NULL,
+    actor_id uuid,
+    entity_type TEXT NOT NULL,
+    entity_id uuid NOT NULL,
+    subject TEXT NOT NULL DEFAULT '',
+    changes JSONB,
+    read_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT now() NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE,
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_notifications_user ON notifications (user_id, created_at DESC);
+CREATE INDEX idx_notifications_unread ON notifications (user_id) WHERE read_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS notification_preferences(
+    user_id uuid NOT NULL,
+    type TEXT NOT NULL,
+    in_app BOOLEAN NOT NULL DEFAULT true,
+    PRIMARY KEY (user_id, type),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS notifications;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS in_app BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS email_pending BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX idx_notifications_email_pending ON notifications (user_id) WHERE email_pending;
+
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS email BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS notification_settings(
+    user_id uuid NOT NULL,
+    email_delivery TEXT NOT NULL DEFAULT 'immediate',
+    last_digest_at TIMESTAMP,
+    PRIMARY KEY (user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS notification_settings;
+ALTER TABLE notification_preferences DROP COLUMN IF EXISTS email;
+DROP INDEX IF EXISTS idx_notifications_email_pending;
+ALTER TABLE notifications DROP COLUMN IF EXISTS email_pending;
+ALTER TABLE notifications DROP COLUMN IF EXISTS in_app;
+-- +goose StatementEnd
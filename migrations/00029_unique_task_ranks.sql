@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+-- columns where concurrent moves left equal ranks are renumbered in their
+-- current order; decimal digits are valid rank digits
+UPDATE tasks AS t SET rank = r.rank
+FROM (
+    SELECT id, lpad((row_number() OVER (PARTITION BY project_id, status ORDER BY rank, id) * 1000)::text, 10, '0') AS rank
+    FROM tasks
+    WHERE (project_id, status) IN (
+        SELECT project_id, status FROM tasks
+        GROUP BY project_id, status, rank
+        HAVING count(*) > 1
+    )
+) AS r
+WHERE t.id = r.id;
+
+DROP INDEX IF EXISTS idx_tasks_column_rank;
+
+-- checked at commit, so that spreading out the ranks of a column may pass
+-- through equal ranks one task at a time
+ALTER TABLE tasks ADD CONSTRAINT tasks_column_rank_key UNIQUE (project_id, status, rank)
+    DEFERRABLE INITIALLY DEFERRED;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_column_rank_key;
+CREATE INDEX idx_tasks_column_rank ON tasks (project_id, status, rank);
+-- +goose StatementEnd
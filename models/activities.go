@@ -0,0 +1,52 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Actions recorded in the activity log.
+const (
+	ActionCreated    = "created"
+	ActionUpdated    = "updated"
+	ActionDeleted    = "deleted"
+	ActionAdded      = "added"
+	ActionRemoved    = "removed"
+	ActionAssigned   = "assigned"
+	ActionUnassigned = "unassigned"
+	ActionDeclined   = "declined"
+	ActionRevoked    = "revoked"
+)
+
+// Change holds the value of a field before and after a mutation.
+type Change struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// Activity is an entry in a workspace's append-only audit trail.
+type Activity struct {
+	Id          uuid.UUID         `json:"id"`
+	WorkspaceId uuid.UUID         `json:"workspaceId"`
+	Actor       *User             `json:"actor,omitempty"`
+	EntityType  Resource          `json:"entityType"`
+	EntityId    uuid.UUID         `json:"entityId"`
+	Action      string            `json:"action"`
+	Changes     map[string]Change `json:"changes,omitempty"`
+	CreatedAt   time.Time         `json:"createdAt"`
+}
+
+// ActivityFilter narrows an activity listing.
+type ActivityFilter struct {
+	Actor      uuid.UUID `json:"actor,omitzero"`
+	EntityType Resource  `json:"entityType,omitempty"`
+	EntityId   uuid.UUID `json:"entityId,omitzero"`
+	ListOptions
+}
+
+type ActivityStore interface {
+	InsertActivity(ctx context.Context, activity *Activity) error
+	GetActivities(ctx context.Context, workspaceId uuid.UUID, filter ActivityFilter) (*Page[Activity], error)
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Attachment is a file uploaded to a task. The contents are kept in blob
+// storage under Key; ContentType is detected from the contents rather than
+// taken from the client.
+type Attachment struct {
+	Id          uuid.UUID `json:"id"`
+	TaskId      uuid.UUID `json:"taskId"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	Key         string    `json:"-"`
+	UploadedBy  uuid.UUID `json:"uploadedBy,omitzero"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type AttachmentStore interface {
+	CreateAttachment(ctx context.Context, attachment *Attachment) error
+	GetAttachment(ctx context.Context, id uuid.UUID) (*Attachment, error)
+	GetTaskAttachments(ctx context.Context, taskId uuid.UUID) ([]Attachment, error)
+	DeleteAttachment(ctx context.Context, id uuid.UUID) error
+}
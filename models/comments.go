@@ -0,0 +1,32 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Comment is a message left on a task. Top-level comments carry their
+// replies; replies cannot be replied to.
+type Comment struct {
+	Id           uuid.UUID   `json:"id"`
+	TaskId       uuid.UUID   `json:"taskId"`
+	ParentId     uuid.UUID   `json:"parentId,omitzero"`
+	Author       *User       `json:"author"`
+	Body         string      `json:"body"`
+	Mentions     []User      `json:"mentions"`
+	EditHistory  []time.Time `json:"editHistory,omitempty"`
+	Replies      []Comment   `json:"replies,omitempty"`
+	CreatedAt    time.Time   `json:"createdAt"`
+	LastModified time.Time   `json:"lastModified"`
+}
+
+type CommentStore interface {
+	CreateComment(ctx context.Context, comment *Comment) error
+	GetComment(ctx context.Context, id uuid.UUID) (*Comment, error)
+	GetTaskComments(ctx context.Context, taskId uuid.UUID) ([]Comment, error)
+	UpdateComment(ctx context.Context, comment *Comment, previousBody string) error
+	DeleteComment(ctx context.Context, id uuid.UUID) error
+	GetMentionableUsers(ctx context.Context, workspaceId uuid.UUID, handles []string) ([]User, error)
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationAccepted InvitationStatus = "accepted"
+	InvitationDeclined InvitationStatus = "declined"
+	InvitationRevoked  InvitationStatus = "revoked"
+	// InvitationExpired is reported for pending invitations past their
+	// expiry; it is never stored.
+	InvitationExpired InvitationStatus = "expired"
+)
+
+// Invitation offers membership of a workspace to an email address, which
+// need not belong to a registered user yet.
+type Invitation struct {
+	Id          uuid.UUID        `json:"id"`
+	Workspace   *Workspace       `json:"workspace"`
+	Email       string           `json:"email"`
+	Role        Role             `json:"role"`
+	InvitedBy   *User            `json:"invitedBy,omitempty"`
+	Status      InvitationStatus `json:"status"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	ExpiresAt   time.Time        `json:"expiresAt"`
+	RespondedAt time.Time        `json:"respondedAt,omitzero"`
+}
+
+type InvitationStore interface {
+	// CreateInvitation stores a pending invitation, revoking any other
+	// pending invitation to the same email in the workspace.
+	CreateInvitation(ctx context.Context, invitation *Invitation) error
+	GetInvitation(ctx context.Context, id uuid.UUID) (*Invitation, error)
+	GetWorkspaceInvitations(ctx context.Context, workspaceId uuid.UUID) ([]Invitation, error)
+	GetPendingInvitations(ctx context.Context, email string) ([]Invitation, error)
+	// AcceptInvitation marks a pending invitation accepted and adds the user
+	// to its workspace. It returns ErrNotFound if the invitation is no longer
+	// pending.
+	AcceptInvitation(ctx context.Context, invitation *Invitation, userId uuid.UUID) error
+	// CloseInvitation sets the status of a pending invitation. It returns
+	// ErrNotFound if the invitation is no longer pending.
+	CloseInvitation(ctx context.Context, invitation *Invitation) error
+	HasMemberWithEmail(ctx context.Context, workspaceId uuid.UUID, email string) (bool, error)
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Label categorizes tasks within a workspace. Names are unique per workspace
+// regardless of case.
+type Label struct {
+	Id           uuid.UUID `json:"id"`
+	WorkspaceId  uuid.UUID `json:"workspaceId"`
+	Name         string    `json:"name"`
+	Color        string    `json:"color"`
+	CreatedAt    time.Time `json:"createdAt"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+type LabelStore interface {
+	CreateLabel(ctx context.Context, label *Label) error
+	GetLabel(ctx context.Context, id uuid.UUID) (*Label, error)
+	GetWorkspaceLabels(ctx context.Context, workspaceId uuid.UUID) ([]Label, error)
+	UpdateLabel(ctx context.Context, label *Label) error
+	DeleteLabel(ctx context.Context, id uuid.UUID) error
+	AttachLabel(ctx context.Context, taskId, labelId uuid.UUID) error
+	DetachLabel(ctx context.Context, taskId, labelId uuid.UUID) error
+	// GetLabelsForTasks returns the labels of each of the tasks, keyed by
+	// task id.
+	GetLabelsForTasks(ctx context.Context, taskIds []uuid.UUID) (map[uuid.UUID][]Label, error)
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Milestone is a target date in a project that tasks can be linked to.
+// Tasks and TasksDone count the linked tasks; Percent and Overdue are derived
+// from them and the due date when the milestone is read.
+type Milestone struct {
+	Id           uuid.UUID `json:"id"`
+	ProjectId    uuid.UUID `json:"projectId"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	Due          Date      `json:"due,omitzero"`
+	Tasks        int       `json:"tasks"`
+	TasksDone    int       `json:"tasksDone"`
+	Percent      int       `json:"percentComplete"`
+	Overdue      bool      `json:"overdue"`
+	CreatedAt    time.Time `json:"createdAt"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+type MilestoneStore interface {
+	CreateMilestone(ctx context.Context, milestone *Milestone) error
+	GetMilestone(ctx context.Context, id uuid.UUID) (*Milestone, error)
+	// GetProjectMilestones returns the milestones of a project ordered by
+	// due date, with the ones without a due date last.
+	GetProjectMilestones(ctx context.Context, projectId uuid.UUID) ([]Milestone, error)
+	UpdateMilestone(ctx context.Context, milestone *Milestone) error
+	DeleteMilestone(ctx context.Context, id uuid.UUID) error
+}
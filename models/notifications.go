@@ -0,0 +1,166 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationType identifies the event a notification was created for.
+type NotificationType string
+
+const (
+	NotificationTaskAssigned NotificationType = "task_assigned"
+	NotificationTaskUpdated  NotificationType = "task_updated"
+	NotificationTaskStatus   NotificationType = "task_status_changed"
+	NotificationTaskDueSoon  NotificationType = "task_due_soon"
+	NotificationMemberAdded  NotificationType = "member_added"
+)
+
+// NotificationTypes lists every notification type users can configure.
+var NotificationTypes = []NotificationType{
+	NotificationTaskAssigned,
+	NotificationTaskUpdated,
+	NotificationTaskStatus,
+	NotificationTaskDueSoon,
+	NotificationMemberAdded,
+}
+
+// Valid reports whether t is one of the known notification types.
+func (t NotificationType) Valid() bool {
+	switch t {
+	case NotificationTaskAssigned, NotificationTaskUpdated, NotificationTaskStatus, NotificationTaskDueSoon, NotificationMemberAdded:
+		return true
+	}
+	return false
+}
+
+// DefaultPreference returns the preference of users who never configured
+// notifications of type t. Every notification is shown in the app, and only
+// the ones that need the user's attention are emailed.
+func (t NotificationType) DefaultPreference() NotificationPreference {
+	switch t {
+	case NotificationTaskAssigned, NotificationTaskStatus, NotificationTaskDueSoon:
+		return NotificationPreference{Type: t, InApp: true, Email: true}
+	}
+	return NotificationPreference{Type: t, InApp: true}
+}
+
+// EmailDelivery is how a user receives notification emails.
+type EmailDelivery string
+
+const (
+	// DeliveryImmediate sends an email for each notification as it happens.
+	DeliveryImmediate EmailDelivery = "immediate"
+	// DeliveryDigest collects notifications into one email a day.
+	DeliveryDigest EmailDelivery = "digest"
+)
+
+// Valid reports whether d is one of the known delivery modes.
+func (d EmailDelivery) Valid() bool {
+	return d == DeliveryImmediate || d == DeliveryDigest
+}
+
+// Notification tells a user about something another member did that
+// concerns them. Subject is the title or name of the entity at the time the
+// notification was created, so the notification stays readable after the
+// entity is renamed or deleted. Notifications the user turned off in the app
+// are still stored while their email is pending, but are never listed.
+type Notification struct {
+	Id          uuid.UUID         `json:"id"`
+	UserId      uuid.UUID         `json:"-"`
+	Type        NotificationType  `json:"type"`
+	WorkspaceId uuid.UUID         `json:"workspaceId"`
+	Actor       *User             `json:"actor,omitempty"`
+	EntityType  Resource          `json:"entityType"`
+	EntityId    uuid.UUID         `json:"entityId"`
+	Subject     string            `json:"subject"`
+	Changes     map[string]Change `json:"changes,omitempty"`
+	ReadAt      time.Time         `json:"readAt,omitzero"`
+	CreatedAt   time.Time         `json:"createdAt"`
+
+	InApp        bool `json:"-"`
+	EmailPending bool `json:"-"`
+}
+
+// NotificationFilter narrows a notification listing.
+type NotificationFilter struct {
+	Unread bool `json:"unread,omitempty"`
+	ListOptions
+}
+
+// NotificationPage is a page of a user's notifications along with the number
+// of notifications they have not read.
+type NotificationPage struct {
+	Page[Notification]
+	Unread int `json:"unread"`
+}
+
+// NotificationPreference controls whether a user receives notifications of
+// a type in the app and by email. Types without a stored preference use
+// their DefaultPreference.
+type NotificationPreference struct {
+	Type  NotificationType `json:"type"`
+	InApp bool             `json:"inApp"`
+	Email bool             `json:"email"`
+}
+
+// NotificationSettings holds everything needed to deliver notifications to
+// a user.
+type NotificationSettings struct {
+	User         User                     `json:"-"`
+	Delivery     EmailDelivery            `json:"delivery"`
+	Preferences  []NotificationPreference `json:"preferences"`
+	LastDigestAt time.Time                `json:"lastDigestAt,omitzero"`
+}
+
+// Preference returns the user's preference for notifications of type t.
+func (s *NotificationSettings) Preference(t NotificationType) NotificationPreference {
+	for _, pref := range s.Preferences {
+		if pref.Type == t {
+			return pref
+		}
+	}
+	return t.DefaultPreference()
+}
+
+// DueReminder is an assignment of a task due soon that its assignee has not
+// been reminded of yet.
+type DueReminder struct {
+	Task        Task
+	UserId      uuid.UUID
+	WorkspaceId uuid.UUID
+}
+
+type NotificationStore interface {
+	CreateNotification(ctx context.Context, notification *Notification) error
+	GetNotifications(ctx context.Context, userId uuid.UUID, filter NotificationFilter) (*Page[Notification], error)
+	CountUnreadNotifications(ctx context.Context, userId uuid.UUID) (int, error)
+	// MarkNotificationRead marks one of the user's notifications read. It
+	// returns ErrNotFound if the user has no such notification.
+	MarkNotificationRead(ctx context.Context, userId, id uuid.UUID, readAt time.Time) error
+	// MarkAllNotificationsRead marks every unread notification of the user
+	// read and returns how many there were.
+	MarkAllNotificationsRead(ctx context.Context, userId uuid.UUID, readAt time.Time) (int64, error)
+	// GetNotificationSettings returns the user's delivery settings and their
+	// preference for every notification type, using the defaults for types
+	// never configured.
+	GetNotificationSettings(ctx context.Context, userId uuid.UUID) (*NotificationSettings, error)
+	SetNotificationPreferences(ctx context.Context, userId uuid.UUID, prefs []NotificationPreference) error
+	SetEmailDelivery(ctx context.Context, userId uuid.UUID, delivery EmailDelivery) error
+	// GetDueReminders returns the assignments of tasks that are not done and
+	// due within window before the given time, leaving out those already
+	// reminded of since the window before their due time began.
+	GetDueReminders(ctx context.Context, before time.Time, window time.Duration) ([]DueReminder, error)
+	// GetDigestRecipients returns the users with pending notification emails
+	// that were last sent a digest before the given time. Users who switch
+	// back to immediate emails are sent one last digest of what was pending.
+	GetDigestRecipients(ctx context.Context, sentBefore time.Time) ([]uuid.UUID, error)
+	// GetPendingEmails returns the notifications waiting for the user's
+	// next digest, oldest first.
+	GetPendingEmails(ctx context.Context, userId uuid.UUID) ([]Notification, error)
+	// MarkDigestSent clears the pending emails of the given notifications and
+	// records when the user's digest was sent.
+	MarkDigestSent(ctx context.Context, userId uuid.UUID, ids []uuid.UUID, sentAt time.Time) error
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MailStatus is the delivery state of an outbound email.
+type MailStatus string
+
+const (
+	MailPending MailStatus = "pending"
+	MailSent    MailStatus = "sent"
+	// MailDead marks emails that failed too many times to be retried.
+	MailDead MailStatus = "dead"
+)
+
+// OutboxMail is an email waiting in the outbox to be delivered. Message is
+// the composed message, encoded as JSON.
+type OutboxMail struct {
+	Id            uuid.UUID       `json:"id"`
+	Message       json.RawMessage `json:"message"`
+	Status        MailStatus      `json:"status"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"nextAttemptAt"`
+	LastError     string          `json:"lastError,omitempty"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	SentAt        time.Time       `json:"sentAt,omitzero"`
+}
+
+// Transactor runs a function in a database transaction. Stores called with
+// the context passed to fn take part in the transaction, which is committed
+// if fn returns nil and rolled back otherwise.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type OutboxStore interface {
+	EnqueueMail(ctx context.Context, mail *OutboxMail) error
+	// ClaimMail returns up to limit pending emails due for an attempt and
+	// hides them from other claims for lease, so that several workers never
+	// send the same email.
+	ClaimMail(ctx context.Context, limit int, lease time.Duration) ([]OutboxMail, error)
+	MarkMailSent(ctx context.Context, id uuid.UUID, sentAt time.Time) error
+	// RetryMail records a failed attempt and when to try again.
+	RetryMail(ctx context.Context, id uuid.UUID, attempts int, nextAttemptAt time.Time, lastError string) error
+	// BuryMail records a failed attempt and gives up on the email.
+	BuryMail(ctx context.Context, id uuid.UUID, attempts int, lastError string) error
+}
@@ -40,21 +40,31 @@ func (d *Date) UnmarshalJSON(data []byte) error {
 }
 
 type Project struct {
-	Id           uuid.UUID  `json:"id"`
-	Name         string     `json:"name"`
-	Description  string     `json:"description"`
-	Workspace    *Workspace `json:"workspace,omitempty"`
-	StartDate    Date       `json:"startDate,omitzero"`
-	EndDate      Date       `json:"endDate,omitzero"`
-	Status       string     `json:"status"`
-	CreatedAt    time.Time  `json:"createdAt"`
-	LastModified time.Time  `json:"lastModified"`
+	Id           uuid.UUID       `json:"id"`
+	Name         string          `json:"name"`
+	Description  string          `json:"description"`
+	Workspace    *Workspace      `json:"workspace,omitempty"`
+	StartDate    Date            `json:"startDate,omitzero"`
+	EndDate      Date            `json:"endDate,omitzero"`
+	Status       string          `json:"status"`
+	Statuses     []ProjectStatus `json:"statuses,omitempty"`
+	CreatedAt    time.Time       `json:"createdAt"`
+	LastModified time.Time       `json:"lastModified"`
+}
+
+// ProjectFilter narrows a project listing.
+type ProjectFilter struct {
+	Search string `json:"search,omitempty"`
+	ListOptions
 }
 
 type ProjectStore interface {
 	CreateProject(ctx context.Context, project *Project) error
 	UpdateProject(ctx context.Context, project *Project) error
 	GetProject(ctx context.Context, id uuid.UUID) (*Project, error)
-	GetWorkspaceProjects(ctx context.Context, workspaceId uuid.UUID) ([]Project, error)
+	GetWorkspaceProjects(ctx context.Context, workspaceId uuid.UUID, filter ProjectFilter) (*Page[Project], error)
 	DeleteProject(ctx context.Context, id uuid.UUID) error
+	StatusStore
+	SprintStore
+	MilestoneStore
 }
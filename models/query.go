@@ -0,0 +1,28 @@
+package models
+
+import "errors"
+
+var (
+	ErrInvalidQuery = errors.New("invalid list query")
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 100
+)
+
+// ListOptions controls the size, position and ordering of a paginated listing.
+// Cursor is the opaque NextCursor value of a previous page.
+type ListOptions struct {
+	Limit  int    `json:"limit,omitempty"`
+	Cursor string `json:"-"`
+	Sort   string `json:"sort,omitempty"`
+	Desc   bool   `json:"desc,omitempty"`
+}
+
+// Page is a single page of a cursor paginated listing. NextCursor is empty
+// on the last page.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
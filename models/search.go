@@ -0,0 +1,34 @@
+package models
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// SearchResult is a task or project matching a full-text search. Title and
+// Snippet are HTML escaped, with the matched words wrapped in <mark> tags.
+// ProjectId is the project's own id for project results.
+type SearchResult struct {
+	Type      Resource  `json:"type"`
+	Id        uuid.UUID `json:"id"`
+	ProjectId uuid.UUID `json:"projectId"`
+	Title     string    `json:"title"`
+	Snippet   string    `json:"snippet"`
+	Rank      float32   `json:"rank"`
+}
+
+// SearchFilter is a full-text search within a workspace. Query uses web
+// search syntax: quoted phrases, "or" and "-" to exclude words. Types
+// narrows the results to tasks or projects.
+type SearchFilter struct {
+	Query string     `json:"q"`
+	Types []Resource `json:"type,omitempty"`
+	ListOptions
+}
+
+type SearchStore interface {
+	// Search returns the tasks and projects of a workspace matching filter,
+	// best match first by default.
+	Search(ctx context.Context, workspaceId uuid.UUID, filter SearchFilter) (*Page[SearchResult], error)
+}
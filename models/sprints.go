@@ -0,0 +1,63 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SprintState is the stage of a sprint. Sprints start out planned, at most
+// one sprint of a project is active at a time and closed sprints are final.
+type SprintState string
+
+const (
+	SprintPlanned SprintState = "planned"
+	SprintActive  SprintState = "active"
+	SprintClosed  SprintState = "closed"
+)
+
+// Sprint is a time-boxed iteration of a project that tasks can be planned
+// into.
+type Sprint struct {
+	Id           uuid.UUID   `json:"id"`
+	ProjectId    uuid.UUID   `json:"projectId"`
+	Name         string      `json:"name"`
+	Goal         string      `json:"goal"`
+	StartDate    Date        `json:"startDate,omitzero"`
+	EndDate      Date        `json:"endDate,omitzero"`
+	State        SprintState `json:"state"`
+	StartedAt    time.Time   `json:"startedAt,omitzero"`
+	ClosedAt     time.Time   `json:"closedAt,omitzero"`
+	CreatedAt    time.Time   `json:"createdAt"`
+	LastModified time.Time   `json:"lastModified"`
+}
+
+// SprintSummary compares the tasks a sprint committed to when it started
+// with the tasks it held when it closed, or holds now if it is still open.
+// Committed is zero until the sprint starts.
+type SprintSummary struct {
+	Committed          int `json:"committed"`
+	Added              int `json:"added"`
+	Removed            int `json:"removed"`
+	Completed          int `json:"completed"`
+	Incomplete         int `json:"incomplete"`
+	CommittedCompleted int `json:"committedCompleted"`
+}
+
+type SprintStore interface {
+	CreateSprint(ctx context.Context, sprint *Sprint) error
+	GetSprint(ctx context.Context, id uuid.UUID) (*Sprint, error)
+	GetProjectSprints(ctx context.Context, projectId uuid.UUID) ([]Sprint, error)
+	UpdateSprint(ctx context.Context, sprint *Sprint) error
+	DeleteSprint(ctx context.Context, id uuid.UUID) error
+	// StartSprint makes a sprint active and records the tasks it holds as
+	// its commitment.
+	StartSprint(ctx context.Context, sprint *Sprint) error
+	// CloseSprint closes a sprint, recording which of its tasks were done,
+	// and moves the rest to the sprint carryOverTo or, if it is uuid.Nil,
+	// back to the backlog. It returns the number of tasks moved, or
+	// ErrNotFound if the sprint is no longer active.
+	CloseSprint(ctx context.Context, sprint *Sprint, carryOverTo uuid.UUID) (int, error)
+	GetSprintSummary(ctx context.Context, sprint *Sprint) (*SprintSummary, error)
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatusCategory groups the statuses of project workflows so that tasks can
+// be compared across projects.
+type StatusCategory string
+
+const (
+	CategoryOpen       StatusCategory = "open"
+	CategoryInProgress StatusCategory = "in_progress"
+	CategoryDone       StatusCategory = "done"
+)
+
+// Valid reports whether c is one of the known categories.
+func (c StatusCategory) Valid() bool {
+	switch c {
+	case CategoryOpen, CategoryInProgress, CategoryDone:
+		return true
+	}
+	return false
+}
+
+// ProjectStatus is a step in a project's workflow. Tasks refer to their
+// status by its key, which cannot be changed once created.
+type ProjectStatus struct {
+	Id           uuid.UUID      `json:"id"`
+	ProjectId    uuid.UUID      `json:"projectId"`
+	Key          TaskStatus     `json:"key"`
+	Name         string         `json:"name"`
+	Category     StatusCategory `json:"category"`
+	Position     int            `json:"position"`
+	CreatedAt    time.Time      `json:"createdAt"`
+	LastModified time.Time      `json:"lastModified"`
+}
+
+// StatusTransition allows tasks to move from one status to another. A
+// project without transitions lets tasks move between any of its statuses.
+type StatusTransition struct {
+	From TaskStatus `json:"from" binding:"required"`
+	To   TaskStatus `json:"to" binding:"required"`
+}
+
+// DefaultStatuses is the workflow new projects start with.
+var DefaultStatuses = []ProjectStatus{
+	{Key: StatusTodo, Name: "To do", Category: CategoryOpen},
+	{Key: StatusInProgress, Name: "In progress", Category: CategoryInProgress},
+	{Key: StatusDone, Name: "Complete", Category: CategoryDone},
+}
+
+type StatusStore interface {
+	CreateStatus(ctx context.Context, status *ProjectStatus) error
+	GetStatus(ctx context.Context, id uuid.UUID) (*ProjectStatus, error)
+	// GetProjectStatuses returns the statuses of a project ordered by
+	// position.
+	GetProjectStatuses(ctx context.Context, projectId uuid.UUID) ([]ProjectStatus, error)
+	UpdateStatus(ctx context.Context, status *ProjectStatus) error
+	DeleteStatus(ctx context.Context, id uuid.UUID) error
+	GetStatusTransitions(ctx context.Context, projectId uuid.UUID) ([]StatusTransition, error)
+	// SetStatusTransitions replaces the transitions of a project.
+	SetStatusTransitions(ctx context.Context, projectId uuid.UUID, transitions []StatusTransition) error
+}
@@ -7,9 +7,10 @@ import (
 	"github.com/google/uuid"
 )
 
-// TaskStatus defines allowed statuses for a task.
+// TaskStatus is the key of a status in the workflow of a task's project.
 type TaskStatus string
 
+// Keys of the statuses in DefaultStatuses.
 const (
 	StatusTodo       TaskStatus = "todo"
 	StatusInProgress TaskStatus = "started"
@@ -25,26 +26,138 @@ const (
 	PriorityHigh   TaskPriority = "high"
 )
 
-// Task represents a single work item within a project.
+// MaxTaskDepth is the number of levels a task hierarchy may have, counting
+// the top-level task.
+const MaxTaskDepth = 3
+
+// Task represents a single work item within a project. A task may be the
+// subtask of another task in the same project. Rank orders the tasks that
+// share a status, as on a board column. Estimate and TimeSpent are the
+// planned and logged time in seconds.
 type Task struct {
-	Id           uuid.UUID    `json:"id"`
-	Title        string       `json:"title"`
-	Description  string       `json:"description"`
-	Project      *Project     `json:"project,omitzero"`
-	Status       TaskStatus   `json:"status"`
-	Priority     TaskPriority `json:"priority"`
-	Due          time.Time    `json:"due,omitzero"`
-	CreatedAt    time.Time    `json:"createdAt"`
-	LastModified time.Time    `json:"lastModified"`
+	Id           uuid.UUID       `json:"id"`
+	Title        string          `json:"title"`
+	Description  string          `json:"description"`
+	Project      *Project        `json:"project,omitzero"`
+	ParentId     uuid.UUID       `json:"parentId,omitzero"`
+	SprintId     uuid.UUID       `json:"sprintId,omitzero"`
+	MilestoneId  uuid.UUID       `json:"milestoneId,omitzero"`
+	Status       TaskStatus      `json:"status"`
+	Category     StatusCategory  `json:"statusCategory"`
+	Rank         string          `json:"rank"`
+	Priority     TaskPriority    `json:"priority"`
+	Due          time.Time       `json:"due,omitzero"`
+	Estimate     int64           `json:"estimate"`
+	TimeSpent    int64           `json:"timeSpent"`
+	Blocked      bool            `json:"blocked"`
+	Labels       []Label         `json:"labels"`
+	Progress     TaskProgress    `json:"progress"`
+	Checklist    []ChecklistItem `json:"checklist,omitempty"`
+	CreatedAt    time.Time       `json:"createdAt"`
+	LastModified time.Time       `json:"lastModified"`
+}
+
+// TaskRank is the position of a task within its status column.
+type TaskRank struct {
+	Id   uuid.UUID `json:"id"`
+	Rank string    `json:"rank"`
+}
+
+// TaskDependencies lists the tasks a task is blocked by and the tasks it
+// blocks. A task is blocked while any of its blockers is not done.
+type TaskDependencies struct {
+	BlockedBy []Task `json:"blockedBy"`
+	Blocks    []Task `json:"blocks"`
+}
+
+// TaskProgress counts the direct subtasks and checklist items of a task and
+// how many of them are done.
+type TaskProgress struct {
+	Subtasks      int `json:"subtasks"`
+	SubtasksDone  int `json:"subtasksDone"`
+	Checklist     int `json:"checklist"`
+	ChecklistDone int `json:"checklistDone"`
+}
+
+// ChecklistItem is a lightweight to-do on a task.
+type ChecklistItem struct {
+	Id           uuid.UUID `json:"id"`
+	TaskId       uuid.UUID `json:"taskId"`
+	Title        string    `json:"title"`
+	Done         bool      `json:"done"`
+	Position     int       `json:"position"`
+	CreatedAt    time.Time `json:"createdAt"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+// TaskFilter narrows a task listing. DueFrom is inclusive and DueTo is
+// exclusive; zero values are ignored. Tasks match Labels if they carry any
+// of them. Backlog matches the tasks that are not in a sprint. Mine matches
+// the tasks assigned to whoever runs the filter and is resolved to Assignee
+// before the listing is read. Overdue matches tasks past their due date that
+// are not done.
+type TaskFilter struct {
+	Status    []TaskStatus   `json:"status,omitempty"`
+	Priority  []TaskPriority `json:"priority,omitempty"`
+	Assignee  uuid.UUID      `json:"assignee,omitzero"`
+	Mine      bool           `json:"mine,omitempty"`
+	Labels    []uuid.UUID    `json:"labels,omitempty"`
+	Sprint    uuid.UUID      `json:"sprint,omitzero"`
+	Backlog   bool           `json:"backlog,omitempty"`
+	Milestone uuid.UUID      `json:"milestone,omitzero"`
+	DueFrom   time.Time      `json:"dueFrom,omitzero"`
+	DueTo     time.Time      `json:"dueTo,omitzero"`
+	Overdue   bool           `json:"overdue,omitempty"`
+	Search    string         `json:"search,omitempty"`
+	ListOptions
 }
 
 type TaskStore interface {
 	CreateTask(ctx context.Context, task *Task) error
 	UpdateTask(ctx context.Context, task *Task) error
 	GetTask(ctx context.Context, id uuid.UUID) (*Task, error)
-	GetTasksForProject(ctx context.Context, projectId uuid.UUID) ([]Task, error)
+	GetTasksForProject(ctx context.Context, projectId uuid.UUID, filter TaskFilter) (*Page[Task], error)
+	GetTasksForWorkspace(ctx context.Context, workspaceId uuid.UUID, filter TaskFilter) (*Page[Task], error)
 	DeleteTask(ctx context.Context, id uuid.UUID) error
 	AssignTask(ctx context.Context, taskId, userId uuid.UUID) error
 	UnassignTask(ctx context.Context, taskId, userId uuid.UUID) error
 	GetAssignedUsers(ctx context.Context, taskId uuid.UUID) ([]User, error)
+	GetSubtasks(ctx context.Context, parentId uuid.UUID) ([]Task, error)
+	// GetTaskAncestors returns the id of the task followed by those of its
+	// parent, grandparent and so on.
+	GetTaskAncestors(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	// GetSubtaskDepth returns the number of levels of subtasks below a task.
+	GetSubtaskDepth(ctx context.Context, id uuid.UUID) (int, error)
+	// GetColumnRanks returns the ranks of the tasks of a project in a
+	// status, in order. Within a transaction it also locks the column until
+	// the transaction ends, so that tasks are placed in it one at a time.
+	GetColumnRanks(ctx context.Context, projectId uuid.UUID, status TaskStatus) ([]TaskRank, error)
+	// SetTaskRanks changes the ranks of several tasks. Ranks are unique
+	// within a column once a transaction commits, so it has to run within
+	// the transaction that locked the column.
+	SetTaskRanks(ctx context.Context, ranks []TaskRank) error
+	ChecklistStore
+	DependencyStore
+	AttachmentStore
+	TimeStore
+}
+
+type DependencyStore interface {
+	AddDependency(ctx context.Context, taskId, blockerId uuid.UUID) error
+	RemoveDependency(ctx context.Context, taskId, blockerId uuid.UUID) error
+	GetDependencies(ctx context.Context, taskId uuid.UUID) (*TaskDependencies, error)
+	// IsBlockedBy reports whether blockerId blocks taskId directly or
+	// through other tasks.
+	IsBlockedBy(ctx context.Context, taskId, blockerId uuid.UUID) (bool, error)
+	// LockDependencies keeps other transactions from changing the task
+	// dependencies of a workspace until the calling transaction ends.
+	LockDependencies(ctx context.Context, workspaceId uuid.UUID) error
+}
+
+type ChecklistStore interface {
+	CreateChecklistItem(ctx context.Context, item *ChecklistItem) error
+	GetChecklistItem(ctx context.Context, id uuid.UUID) (*ChecklistItem, error)
+	GetChecklist(ctx context.Context, taskId uuid.UUID) ([]ChecklistItem, error)
+	UpdateChecklistItem(ctx context.Context, item *ChecklistItem) error
+	DeleteChecklistItem(ctx context.Context, id uuid.UUID) error
 }
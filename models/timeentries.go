@@ -0,0 +1,80 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TimeEntry is time a user logged on a task, either with a timer or by hand.
+// A running timer has no EndedAt; its Duration is set when it is stopped.
+// Durations are in seconds.
+type TimeEntry struct {
+	Id           uuid.UUID `json:"id"`
+	TaskId       uuid.UUID `json:"taskId"`
+	UserId       uuid.UUID `json:"userId"`
+	Note         string    `json:"note"`
+	StartedAt    time.Time `json:"startedAt"`
+	EndedAt      time.Time `json:"endedAt,omitzero"`
+	Duration     int64     `json:"duration"`
+	CreatedAt    time.Time `json:"createdAt"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+// Running reports whether the entry is a timer that has not been stopped.
+func (e *TimeEntry) Running() bool {
+	return e.EndedAt.IsZero()
+}
+
+// TimeReportFilter narrows the entries counted in a time report. Entries are
+// matched by the time they started; From is inclusive and To is exclusive.
+// Zero values are ignored and running timers are never counted.
+type TimeReportFilter struct {
+	WorkspaceId uuid.UUID `json:"-"`
+	ProjectId   uuid.UUID `json:"project,omitzero"`
+	UserId      uuid.UUID `json:"user,omitzero"`
+	From        time.Time `json:"from,omitzero"`
+	To          time.Time `json:"to,omitzero"`
+}
+
+// TimeLog is the time a user logged on the tasks of a project on one day.
+type TimeLog struct {
+	ProjectId   uuid.UUID
+	ProjectName string
+	UserId      uuid.UUID
+	UserName    string
+	Day         Date
+	Duration    int64
+}
+
+// TimeTotal is the time logged in one project, by one user or on one day.
+type TimeTotal struct {
+	Id       uuid.UUID `json:"id,omitzero"`
+	Name     string    `json:"name,omitempty"`
+	Date     Date      `json:"date,omitzero"`
+	Duration int64     `json:"duration"`
+}
+
+// TimeReport sums the time logged in the entries matching Filter, in total
+// and per project, user and day.
+type TimeReport struct {
+	Filter   TimeReportFilter `json:"filter"`
+	Total    int64            `json:"total"`
+	Projects []TimeTotal      `json:"projects"`
+	Users    []TimeTotal      `json:"users"`
+	Days     []TimeTotal      `json:"days"`
+}
+
+type TimeStore interface {
+	CreateTimeEntry(ctx context.Context, entry *TimeEntry) error
+	GetTimeEntry(ctx context.Context, id uuid.UUID) (*TimeEntry, error)
+	GetTaskTimeEntries(ctx context.Context, taskId uuid.UUID) ([]TimeEntry, error)
+	// GetRunningTimer returns the timer the user has not stopped yet.
+	GetRunningTimer(ctx context.Context, userId uuid.UUID) (*TimeEntry, error)
+	UpdateTimeEntry(ctx context.Context, entry *TimeEntry) error
+	DeleteTimeEntry(ctx context.Context, id uuid.UUID) error
+	// GetTimeLogs returns the time logged in the entries matching filter,
+	// summed per project, user and day.
+	GetTimeLogs(ctx context.Context, filter TimeReportFilter) ([]TimeLog, error)
+}
@@ -12,6 +12,8 @@ var (
 	ErrDuplicateUser = errors.New("user with email already exists")
 )
 
+// User is an account of the application. ProfilePhoto is the URL the
+// uploaded photo stored under PhotoKey is served from.
 type User struct {
 	Id           uuid.UUID `json:"id"`
 	Name         string    `json:"name"`
@@ -19,6 +21,7 @@ type User struct {
 	Role         string    `json:"role,omitempty"`
 	PasswordHash []byte    `json:"-"`
 	ProfilePhoto string    `json:"profilePhoto"`
+	PhotoKey     string    `json:"-"`
 	CreatedAt    time.Time `json:"createdAt"`
 	LastModifed  time.Time `json:"lastModified"`
 	Verified     bool      `json:"verified"`
@@ -29,6 +32,20 @@ type UserToken struct {
 	UserId    uuid.UUID
 	ExpiresAt time.Time
 	Scope     string
+	SessionId uuid.UUID
+	CreatedAt time.Time
+	UserAgent string
+	IPAddress string
+	RotatedAt time.Time
+}
+
+// Session is a signed in device, backed by an authentication token.
+type Session struct {
+	Id        uuid.UUID `json:"id"`
+	UserAgent string    `json:"userAgent"`
+	IPAddress string    `json:"ipAddress"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
 }
 
 type UserStore interface {
@@ -40,4 +57,10 @@ type UserStore interface {
 	InsertToken(ctx context.Context, token *UserToken) error
 	GetUserForToken(ctx context.Context, tokenHash, scope, email string) (User, error)
 	DeleteToken(ctx context.Context, tokenHash, scope string) error
+	DeleteTokensForUser(ctx context.Context, userId uuid.UUID, scope string) error
+	GetToken(ctx context.Context, tokenHash, scope string) (UserToken, error)
+	GetSessions(ctx context.Context, userId uuid.UUID) ([]Session, error)
+	DeleteSession(ctx context.Context, userId, sessionId uuid.UUID) error
+	RotateToken(ctx context.Context, tokenHash string, next *UserToken) error
+	Transactor
 }
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ViewGrouping is the task field the tasks of a view are grouped by when
+// they are displayed.
+type ViewGrouping string
+
+const (
+	GroupNone      ViewGrouping = ""
+	GroupStatus    ViewGrouping = "status"
+	GroupPriority  ViewGrouping = "priority"
+	GroupSprint    ViewGrouping = "sprint"
+	GroupMilestone ViewGrouping = "milestone"
+)
+
+// Valid reports whether g is one of the known groupings.
+func (g ViewGrouping) Valid() bool {
+	switch g {
+	case GroupNone, GroupStatus, GroupPriority, GroupSprint, GroupMilestone:
+		return true
+	}
+	return false
+}
+
+// View is a saved task listing. Views list the tasks of a single project, or
+// of every project in the workspace when ProjectId is not set. A view is
+// private to its owner unless it is shared with the workspace.
+type View struct {
+	Id           uuid.UUID    `json:"id"`
+	WorkspaceId  uuid.UUID    `json:"workspaceId"`
+	ProjectId    uuid.UUID    `json:"projectId,omitzero"`
+	OwnerId      uuid.UUID    `json:"ownerId"`
+	Name         string       `json:"name"`
+	Shared       bool         `json:"shared"`
+	Filter       TaskFilter   `json:"filter"`
+	GroupBy      ViewGrouping `json:"groupBy"`
+	CreatedAt    time.Time    `json:"createdAt"`
+	LastModified time.Time    `json:"lastModified"`
+}
+
+type ViewStore interface {
+	CreateView(ctx context.Context, view *View) error
+	GetView(ctx context.Context, id uuid.UUID) (*View, error)
+	// GetWorkspaceViews returns the views of a workspace that the user owns
+	// or that are shared, ordered by name.
+	GetWorkspaceViews(ctx context.Context, workspaceId, userId uuid.UUID) ([]View, error)
+	UpdateView(ctx context.Context, view *View) error
+	DeleteView(ctx context.Context, id uuid.UUID) error
+}
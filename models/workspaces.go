@@ -12,6 +12,54 @@ var (
 	ErrNotFound = errors.New("entity not found")
 )
 
+// Role defines a member's level of access within a workspace.
+type Role string
+
+const (
+	RoleOwner  Role = "owner"
+	RoleAdmin  Role = "admin"
+	RoleMember Role = "member"
+	RoleViewer Role = "viewer"
+)
+
+var roleRanks = map[Role]int{
+	RoleViewer: 1,
+	RoleMember: 2,
+	RoleAdmin:  3,
+	RoleOwner:  4,
+}
+
+// Valid reports whether r is one of the known workspace roles.
+func (r Role) Valid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// Includes reports whether r grants at least the access of other.
+func (r Role) Includes(other Role) bool {
+	return r.Valid() && roleRanks[r] >= roleRanks[other]
+}
+
+// Resource identifies a kind of entity that is owned by a workspace.
+type Resource string
+
+const (
+	ResourceWorkspace  Resource = "workspace"
+	ResourceProject    Resource = "project"
+	ResourceTask       Resource = "task"
+	ResourceComment    Resource = "comment"
+	ResourceMember     Resource = "member"
+	ResourceInvitation Resource = "invitation"
+	ResourceChecklist  Resource = "checklist_item"
+	ResourceLabel      Resource = "label"
+	ResourceStatus     Resource = "status"
+	ResourceSprint     Resource = "sprint"
+	ResourceMilestone  Resource = "milestone"
+	ResourceAttachment Resource = "attachment"
+	ResourceTimeEntry  Resource = "time_entry"
+	ResourceView       Resource = "view"
+)
+
 // Workspace represents a top-level organizational unit or collaboration space.
 // Projects and Users belong to a Workspace.
 type Workspace struct {
@@ -23,15 +71,31 @@ type Workspace struct {
 	LastModified time.Time `json:"lastModified"`
 }
 
+// MemberFilter narrows a workspace member listing.
+type MemberFilter struct {
+	Role   []Role `json:"role,omitempty"`
+	Search string `json:"search,omitempty"`
+	ListOptions
+}
+
 type WorkspaceStore interface {
 	Create(ctx context.Context, workspace *Workspace) error
 	Update(ctx context.Context, workspace *Workspace) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	Get(ctx context.Context, id uuid.UUID) (*Workspace, error)
 	GetAllForUser(ctx context.Context, userId uuid.UUID) ([]Workspace, error)
-	GetWorkspaceMembers(ctx context.Context, workspaceId uuid.UUID) ([]User, error)
-	AddMembership(ctx context.Context, workspaceId, userId uuid.UUID, role string) error
+	GetWorkspaceMembers(ctx context.Context, workspaceId uuid.UUID, filter MemberFilter) (*Page[User], error)
 	DeleteMembership(ctx context.Context, workspaceId, userId uuid.UUID) error
+	GetMemberRole(ctx context.Context, workspaceId, userId uuid.UUID) (Role, error)
+	GetResourceWorkspace(ctx context.Context, resource Resource, id uuid.UUID) (uuid.UUID, error)
 	ProjectStore
 	TaskStore
+	CommentStore
+	ActivityStore
+	InvitationStore
+	LabelStore
+	SearchStore
+	ViewStore
+	NotificationStore
+	Transactor
 }
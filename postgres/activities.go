@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// InsertActivity implements models.WorkspaceStore.
+func (w *WorkspaceStore) InsertActivity(ctx context.Context, activity *models.Activity) error {
+	query := `INSERT INTO activities(id, workspace_id, actor_id, entity_type, entity_id, action, changes, created_at)
+	VALUES($1, $2, NULLIF($3, '00000000-0000-0000-0000-000000000000'::uuid), $4, $5, $6, $7, $8);`
+
+	var actorId uuid.UUID
+	if activity.Actor != nil {
+		actorId = activity.Actor.Id
+	}
+
+	var changes map[string]models.Change
+	if len(activity.Changes) > 0 {
+		changes = activity.Changes
+	}
+
+	_, err := db(ctx, w.conn).Exec(ctx, query,
+		activity.Id,
+		activity.WorkspaceId,
+		actorId,
+		activity.EntityType,
+		activity.EntityId,
+		activity.Action,
+		changes,
+		activity.CreatedAt,
+	)
+	if err != nil {
+		slog.Error("failed to insert activity", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetActivities implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetActivities(ctx context.Context, workspaceId uuid.UUID, filter models.ActivityFilter) (*models.Page[models.Activity], error) {
+	q := &listQuery{
+		columns: `a.id,
+	a.workspace_id,
+	COALESCE(a.actor_id, '00000000-0000-0000-0000-000000000000'),
+	COALESCE(u.name, ''),
+	COALESCE(u.email, ''),
+	a.entity_type,
+	a.entity_id,
+	a.action,
+	a.changes,
+	a.created_at`,
+		from:        "activities AS a LEFT JOIN users AS u ON a.actor_id = u.id",
+		id:          "a.id",
+		sorts:       map[string]sortKey{"created_at": {expr: "a.created_at", cast: "timestamp"}},
+		defaultSort: "created_at",
+	}
+
+	q.where("a.workspace_id = %s", workspaceId)
+
+	if filter.Actor != uuid.Nil {
+		q.where("a.actor_id = %s", filter.Actor)
+	}
+
+	if filter.EntityType != "" {
+		q.where("a.entity_type = %s", string(filter.EntityType))
+	}
+
+	if filter.EntityId != uuid.Nil {
+		q.where("a.entity_id = %s", filter.EntityId)
+	}
+
+	return fetchPage(ctx, db(ctx, w.conn), q, filter.ListOptions, func(rows pgx.Rows, sortValue *string, id *uuid.UUID) (models.Activity, error) {
+		activity := models.Activity{Actor: &models.User{}}
+		err := rows.Scan(&activity.Id, &activity.WorkspaceId, &activity.Actor.Id, &activity.Actor.Name, &activity.Actor.Email, &activity.EntityType, &activity.EntityId, &activity.Action, &activity.Changes, &activity.CreatedAt, sortValue, id)
+		if activity.Actor.Id == uuid.Nil {
+			activity.Actor = nil
+		}
+		return activity, err
+	})
+}
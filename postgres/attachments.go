@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateAttachment implements models.WorkspaceStore.
+func (w *WorkspaceStore) CreateAttachment(ctx context.Context, attachment *models.Attachment) error {
+	query := `INSERT INTO task_attachments(id, task_id, name, content_type, size, storage_key, uploaded_by, created_at)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8);`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query,
+		attachment.Id,
+		attachment.TaskId,
+		attachment.Name,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.Key,
+		attachment.UploadedBy,
+		attachment.CreatedAt,
+	)
+	if err != nil {
+		slog.Error("failed to insert attachment", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+const attachmentColumns = `id,
+	task_id,
+	name,
+	content_type,
+	size,
+	storage_key,
+	COALESCE(uploaded_by, '00000000-0000-0000-0000-000000000000'),
+	created_at`
+
+func scanAttachment(row pgx.Row) (models.Attachment, error) {
+	var attachment models.Attachment
+	err := row.Scan(&attachment.Id, &attachment.TaskId, &attachment.Name, &attachment.ContentType, &attachment.Size, &attachment.Key, &attachment.UploadedBy, &attachment.CreatedAt)
+	return attachment, err
+}
+
+// GetAttachment implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetAttachment(ctx context.Context, id uuid.UUID) (*models.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM task_attachments WHERE id = $1;`
+
+	attachment, err := scanAttachment(db(ctx, w.conn).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		slog.Error("failed to read attachment", "error", err.Error())
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+// GetTaskAttachments implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetTaskAttachments(ctx context.Context, taskId uuid.UUID) ([]models.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM task_attachments
+	WHERE task_id = $1
+	ORDER BY created_at, id;`
+
+	rows, err := db(ctx, w.conn).Query(ctx, query, taskId)
+	if err != nil {
+		slog.Error("failed to query attachments", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := []models.Attachment{}
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			slog.Error("failed to scan attachment", "error", err.Error())
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, rows.Err()
+}
+
+// DeleteAttachment implements models.WorkspaceStore.
+func (w *WorkspaceStore) DeleteAttachment(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM task_attachments WHERE id = $1;`
+
+	result, err := db(ctx, w.conn).Exec(ctx, query, id)
+	if err != nil {
+		slog.Error("failed to delete attachment", "error", err.Error())
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
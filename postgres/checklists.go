@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateChecklistItem implements models.WorkspaceStore. The item is placed
+// at the end of the task's checklist.
+func (w *WorkspaceStore) CreateChecklistItem(ctx context.Context, item *models.ChecklistItem) error {
+	query := `INSERT INTO checklist_items(id, task_id, title, done, position, created_at, last_modified)
+	VALUES($1, $2, $3, $4, (SELECT COALESCE(max(position), 0) + 1 FROM checklist_items WHERE task_id = $2), $5, $6)
+	RETURNING position;`
+
+	err := db(ctx, w.conn).QueryRow(ctx, query, item.Id, item.TaskId, item.Title, item.Done, item.CreatedAt, item.LastModified).Scan(&item.Position)
+	if err != nil {
+		slog.Error("failed to insert checklist item", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+const checklistColumns = `id, task_id, title, done, position, created_at, last_modified`
+
+func scanChecklistItem(row pgx.Row) (models.ChecklistItem, error) {
+	var item models.ChecklistItem
+	err := row.Scan(&item.Id, &item.TaskId, &item.Title, &item.Done, &item.Position, &item.CreatedAt, &item.LastModified)
+	return item, err
+}
+
+// GetChecklistItem implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetChecklistItem(ctx context.Context, id uuid.UUID) (*models.ChecklistItem, error) {
+	query := `SELECT ` + checklistColumns + ` FROM checklist_items WHERE id = $1;`
+
+	item, err := scanChecklistItem(db(ctx, w.conn).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		slog.Error("failed to read checklist item", "error", err.Error())
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// GetChecklist implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetChecklist(ctx context.Context, taskId uuid.UUID) ([]models.ChecklistItem, error) {
+	query := `SELECT ` + checklistColumns + ` FROM checklist_items
+	WHERE task_id = $1
+	ORDER BY position, created_at;`
+
+	rows, err := db(ctx, w.conn).Query(ctx, query, taskId)
+	if err != nil {
+		slog.Error("failed to query checklist", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.ChecklistItem{}
+	for rows.Next() {
+		item, err := scanChecklistItem(rows)
+		if err != nil {
+			slog.Error("failed to scan checklist item", "error", err.Error())
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// UpdateChecklistItem implements models.WorkspaceStore.
+func (w *WorkspaceStore) UpdateChecklistItem(ctx context.Context, item *models.ChecklistItem) error {
+	query := `UPDATE checklist_items
+	SET title = $1, done = $2, position = $3, last_modified = $4
+	WHERE id = $5;`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query, item.Title, item.Done, item.Position, item.LastModified, item.Id)
+	if err != nil {
+		slog.Error("failed to update checklist item", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// DeleteChecklistItem implements models.WorkspaceStore.
+func (w *WorkspaceStore) DeleteChecklistItem(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM checklist_items WHERE id = $1;`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query, id)
+	if err != nil {
+		slog.Error("failed to delete checklist item", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
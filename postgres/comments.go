@@ -0,0 +1,280 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateComment implements models.WorkspaceStore.
+func (w *WorkspaceStore) CreateComment(ctx context.Context, comment *models.Comment) error {
+	query := `INSERT INTO task_comments(id, task_id, author_id, parent_id, body, created_at, last_modified)
+	VALUES($1, $2, $3, NULLIF($4, '00000000-0000-0000-0000-000000000000'::uuid), $5, $6, $7);`
+
+	tx, err := db(ctx, w.conn).Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, query, comment.Id, comment.TaskId, comment.Author.Id, comment.ParentId, comment.Body, comment.CreatedAt, comment.LastModified)
+	if err != nil {
+		slog.Error("failed to insert comment", "error", err.Error())
+		return err
+	}
+
+	err = insertMentions(ctx, tx, comment)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("failed to complete transactions", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func insertMentions(ctx context.Context, tx pgx.Tx, comment *models.Comment) error {
+	query := `INSERT INTO comment_mentions(comment_id, user_id) VALUES($1, $2);`
+
+	for _, user := range comment.Mentions {
+		_, err := tx.Exec(ctx, query, comment.Id, user.Id)
+		if err != nil {
+			slog.Error("failed to insert comment mention", "error", err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+const commentColumns = `c.id,
+	c.task_id,
+	COALESCE(c.parent_id, '00000000-0000-0000-0000-000000000000'),
+	c.body,
+	c.created_at,
+	c.last_modified,
+	u.id,
+	u.name,
+	u.email,
+	u.profile_photo`
+
+func scanComment(row pgx.Row) (models.Comment, error) {
+	comment := models.Comment{Author: &models.User{}, Mentions: []models.User{}}
+	err := row.Scan(&comment.Id, &comment.TaskId, &comment.ParentId, &comment.Body, &comment.CreatedAt, &comment.LastModified, &comment.Author.Id, &comment.Author.Name, &comment.Author.Email, &comment.Author.ProfilePhoto)
+	return comment, err
+}
+
+// GetComment implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetComment(ctx context.Context, id uuid.UUID) (*models.Comment, error) {
+	query := `SELECT ` + commentColumns + `
+	FROM task_comments AS c
+	INNER JOIN users AS u ON c.author_id = u.id
+	WHERE c.id = $1;`
+
+	comment, err := scanComment(db(ctx, w.conn).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		slog.Error("failed to read comment", "error", err.Error())
+		return nil, err
+	}
+
+	comments := []models.Comment{comment}
+	if err := w.loadCommentDetails(ctx, comments); err != nil {
+		return nil, err
+	}
+
+	return &comments[0], nil
+}
+
+// GetTaskComments implements models.WorkspaceStore. Comments are returned
+// flat, oldest first.
+func (w *WorkspaceStore) GetTaskComments(ctx context.Context, taskId uuid.UUID) ([]models.Comment, error) {
+	query := `SELECT ` + commentColumns + `
+	FROM task_comments AS c
+	INNER JOIN users AS u ON c.author_id = u.id
+	WHERE c.task_id = $1
+	ORDER BY c.created_at, c.id;`
+
+	rows, err := db(ctx, w.conn).Query(ctx, query, taskId)
+	if err != nil {
+		slog.Error("failed to query comments", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := []models.Comment{}
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			slog.Error("failed to scan comment", "error", err.Error())
+			return nil, err
+		}
+
+		comments = append(comments, comment)
+	}
+	rows.Close()
+
+	if err := w.loadCommentDetails(ctx, comments); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// loadCommentDetails fills in the mentions and edit history of comments.
+func (w *WorkspaceStore) loadCommentDetails(ctx context.Context, comments []models.Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(comments))
+	index := make(map[uuid.UUID]*models.Comment, len(comments))
+	for i := range comments {
+		ids[i] = comments[i].Id
+		index[comments[i].Id] = &comments[i]
+	}
+
+	mentionQuery := `SELECT cm.comment_id, u.id, u.name, u.email, u.profile_photo
+	FROM comment_mentions AS cm
+	INNER JOIN users AS u ON cm.user_id = u.id
+	WHERE cm.comment_id = ANY($1);`
+
+	rows, err := db(ctx, w.conn).Query(ctx, mentionQuery, ids)
+	if err != nil {
+		slog.Error("failed to query comment mentions", "error", err.Error())
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var commentId uuid.UUID
+		var user models.User
+		if err := rows.Scan(&commentId, &user.Id, &user.Name, &user.Email, &user.ProfilePhoto); err != nil {
+			slog.Error("failed to scan comment mention", "error", err.Error())
+			return err
+		}
+		index[commentId].Mentions = append(index[commentId].Mentions, user)
+	}
+	rows.Close()
+
+	editQuery := `SELECT comment_id, edited_at FROM comment_edits
+	WHERE comment_id = ANY($1)
+	ORDER BY edited_at;`
+
+	rows, err = db(ctx, w.conn).Query(ctx, editQuery, ids)
+	if err != nil {
+		slog.Error("failed to query comment edits", "error", err.Error())
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var commentId uuid.UUID
+		var editedAt time.Time
+		if err := rows.Scan(&commentId, &editedAt); err != nil {
+			slog.Error("failed to scan comment edit", "error", err.Error())
+			return err
+		}
+		index[commentId].EditHistory = append(index[commentId].EditHistory, editedAt)
+	}
+
+	return rows.Err()
+}
+
+// UpdateComment implements models.WorkspaceStore. The previous body is kept
+// in the comment's edit history and its mentions are replaced.
+func (w *WorkspaceStore) UpdateComment(ctx context.Context, comment *models.Comment, previousBody string) error {
+	editQuery := `INSERT INTO comment_edits(comment_id, previous_body, edited_at) VALUES($1, $2, $3);`
+	updateQuery := `UPDATE task_comments SET body = $1, last_modified = $2 WHERE id = $3;`
+	clearQuery := `DELETE FROM comment_mentions WHERE comment_id = $1;`
+
+	tx, err := db(ctx, w.conn).Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, editQuery, comment.Id, previousBody, comment.LastModified)
+	if err != nil {
+		slog.Error("failed to insert comment edit", "error", err.Error())
+		return err
+	}
+
+	_, err = tx.Exec(ctx, updateQuery, comment.Body, comment.LastModified, comment.Id)
+	if err != nil {
+		slog.Error("failed to update comment", "error", err.Error())
+		return err
+	}
+
+	_, err = tx.Exec(ctx, clearQuery, comment.Id)
+	if err != nil {
+		slog.Error("failed to clear comment mentions", "error", err.Error())
+		return err
+	}
+
+	err = insertMentions(ctx, tx, comment)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("failed to complete transactions", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteComment implements models.WorkspaceStore.
+func (w *WorkspaceStore) DeleteComment(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM task_comments WHERE id = $1;`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query, id)
+	if err != nil {
+		slog.Error("failed to delete comment", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetMentionableUsers implements models.WorkspaceStore. A handle matches a
+// member by full email address or by the part of it before the '@'.
+func (w *WorkspaceStore) GetMentionableUsers(ctx context.Context, workspaceId uuid.UUID, handles []string) ([]models.User, error) {
+	query := `SELECT u.id, u.name, u.email, u.profile_photo
+	FROM workspace_memberships AS wm
+	INNER JOIN users AS u ON wm.user_id = u.id
+	WHERE wm.workspace_id = $1
+	AND (lower(u.email) = ANY($2) OR lower(split_part(u.email, '@', 1)) = ANY($2));`
+
+	rows, err := db(ctx, w.conn).Query(ctx, query, workspaceId, handles)
+	if err != nil {
+		slog.Error("failed to query mentionable users", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []models.User{}
+	for rows.Next() {
+		var user models.User
+		if err := rows.Scan(&user.Id, &user.Name, &user.Email, &user.ProfilePhoto); err != nil {
+			slog.Error("failed to scan users", "error", err.Error())
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+)
+
+// AddDependency implements models.WorkspaceStore.
+func (w *WorkspaceStore) AddDependency(ctx context.Context, taskId, blockerId uuid.UUID) error {
+	query := `INSERT INTO task_dependencies(task_id, blocker_id) VALUES($1, $2);`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query, taskId, blockerId)
+	if err != nil {
+		slog.Error("failed to insert task dependency", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// RemoveDependency implements models.WorkspaceStore.
+func (w *WorkspaceStore) RemoveDependency(ctx context.Context, taskId, blockerId uuid.UUID) error {
+	query := `DELETE FROM task_dependencies WHERE task_id = $1 AND blocker_id = $2;`
+
+	tag, err := db(ctx, w.conn).Exec(ctx, query, taskId, blockerId)
+	if err != nil {
+		slog.Error("failed to delete task dependency", "error", err.Error())
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetDependencies implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetDependencies(ctx context.Context, taskId uuid.UUID) (*models.TaskDependencies, error) {
+	blockedByQuery := `SELECT ` + taskListColumns + `
+	FROM task_dependencies AS dep
+	INNER JOIN tasks AS t ON dep.blocker_id = t.id
+	WHERE dep.task_id = $1
+	ORDER BY dep.created_at, t.id;`
+
+	blocksQuery := `SELECT ` + taskListColumns + `
+	FROM task_dependencies AS dep
+	INNER JOIN tasks AS t ON dep.task_id = t.id
+	WHERE dep.blocker_id = $1
+	ORDER BY dep.created_at, t.id;`
+
+	var deps models.TaskDependencies
+	var err error
+
+	deps.BlockedBy, err = w.queryTasks(ctx, blockedByQuery, taskId)
+	if err != nil {
+		return nil, err
+	}
+
+	deps.Blocks, err = w.queryTasks(ctx, blocksQuery, taskId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deps, nil
+}
+
+// IsBlockedBy implements models.WorkspaceStore. UNION rather than UNION ALL
+// keeps the walk finite should the graph already contain a cycle.
+func (w *WorkspaceStore) IsBlockedBy(ctx context.Context, taskId, blockerId uuid.UUID) (bool, error) {
+	query := `WITH RECURSIVE blockers(id) AS (
+		SELECT blocker_id FROM task_dependencies WHERE task_id = $1
+		UNION
+		SELECT d.blocker_id
+		FROM task_dependencies AS d
+		INNER JOIN blockers AS b ON d.task_id = b.id
+	)
+	SELECT EXISTS (SELECT 1 FROM blockers WHERE id = $2);`
+
+	var blocked bool
+	err := db(ctx, w.conn).QueryRow(ctx, query, taskId, blockerId).Scan(&blocked)
+	if err != nil {
+		slog.Error("failed to query task blockers", "error", err.Error())
+		return false, err
+	}
+
+	return blocked, nil
+}
+
+// LockDependencies implements models.WorkspaceStore.
+func (w *WorkspaceStore) LockDependencies(ctx context.Context, workspaceId uuid.UUID) error {
+	query := `SELECT pg_advisory_xact_lock(hashtextextended('task_dependencies/' || $1::text, 0));`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query, workspaceId)
+	if err != nil {
+		slog.Error("failed to lock task dependencies", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateInvitation implements models.WorkspaceStore.
+func (w *WorkspaceStore) CreateInvitation(ctx context.Context, invitation *models.Invitation) error {
+	revokeQuery := `UPDATE workspace_invitations SET status = 'revoked', responded_at = $3
+	WHERE workspace_id = $1 AND lower(email) = lower($2) AND status = 'pending';`
+
+	insertQuery := `INSERT INTO workspace_invitations(id, workspace_id, email, role, invited_by, status, created_at, expires_at)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8);`
+
+	tx, err := db(ctx, w.conn).Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, revokeQuery, invitation.Workspace.Id, invitation.Email, invitation.CreatedAt)
+	if err != nil {
+		slog.Error("failed to revoke previous invitations", "error", err.Error())
+		return err
+	}
+
+	_, err = tx.Exec(ctx, insertQuery,
+		invitation.Id,
+		invitation.Workspace.Id,
+		invitation.Email,
+		invitation.Role,
+		invitation.InvitedBy.Id,
+		invitation.Status,
+		invitation.CreatedAt,
+		invitation.ExpiresAt,
+	)
+	if err != nil {
+		slog.Error("failed to insert invitation", "error", err.Error())
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("failed to complete transactions", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// invitationColumns reports pending invitations past their expiry as expired.
+const invitationColumns = `i.id,
+	i.email,
+	i.role,
+	CASE WHEN i.status = 'pending' AND i.expires_at <= now() THEN 'expired' ELSE i.status END,
+	i.created_at,
+	i.expires_at,
+	COALESCE(i.responded_at, '0001-01-01'),
+	w.id,
+	w.name,
+	w.description,
+	COALESCE(u.id, '00000000-0000-0000-0000-000000000000'),
+	COALESCE(u.name, ''),
+	COALESCE(u.email, '')`
+
+const invitationFrom = `workspace_invitations AS i
+	INNER JOIN workspaces AS w ON i.workspace_id = w.id
+	LEFT JOIN users AS u ON i.invited_by = u.id`
+
+func scanInvitation(row pgx.Row) (models.Invitation, error) {
+	invitation := models.Invitation{Workspace: &models.Workspace{}, InvitedBy: &models.User{}}
+	err := row.Scan(&invitation.Id, &invitation.Email, &invitation.Role, &invitation.Status, &invitation.CreatedAt, &invitation.ExpiresAt, &invitation.RespondedAt,
+		&invitation.Workspace.Id, &invitation.Workspace.Name, &invitation.Workspace.Description,
+		&invitation.InvitedBy.Id, &invitation.InvitedBy.Name, &invitation.InvitedBy.Email)
+	if invitation.InvitedBy.Id == uuid.Nil {
+		invitation.InvitedBy = nil
+	}
+	return invitation, err
+}
+
+func (w *WorkspaceStore) queryInvitations(ctx context.Context, query string, args ...any) ([]models.Invitation, error) {
+	rows, err := db(ctx, w.conn).Query(ctx, query, args...)
+	if err != nil {
+		slog.Error("failed to query invitations", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitations := []models.Invitation{}
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			slog.Error("failed to scan invitation", "error", err.Error())
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	return invitations, rows.Err()
+}
+
+// GetInvitation implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetInvitation(ctx context.Context, id uuid.UUID) (*models.Invitation, error) {
+	query := `SELECT ` + invitationColumns + ` FROM ` + invitationFrom + ` WHERE i.id = $1;`
+
+	invitation, err := scanInvitation(db(ctx, w.conn).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		slog.Error("failed to read invitation", "error", err.Error())
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+// GetWorkspaceInvitations implements models.WorkspaceStore. Invitations are
+// returned newest first.
+func (w *WorkspaceStore) GetWorkspaceInvitations(ctx context.Context, workspaceId uuid.UUID) ([]models.Invitation, error) {
+	query := `SELECT ` + invitationColumns + ` FROM ` + invitationFrom + `
+	WHERE i.workspace_id = $1
+	ORDER BY i.created_at DESC, i.id;`
+
+	return w.queryInvitations(ctx, query, workspaceId)
+}
+
+// GetPendingInvitations implements models.WorkspaceStore. Expired
+// invitations are left out.
+func (w *WorkspaceStore) GetPendingInvitations(ctx context.Context, email string) ([]models.Invitation, error) {
+	query := `SELECT ` + invitationColumns + ` FROM ` + invitationFrom + `
+	WHERE lower(i.email) = lower($1) AND i.status = 'pending' AND i.expires_at > now()
+	ORDER BY i.created_at, i.id;`
+
+	return w.queryInvitations(ctx, query, email)
+}
+
+// closeInvitation updates the status of a pending, unexpired invitation.
+func closeInvitation(ctx context.Context, conn executor, invitation *models.Invitation) error {
+	query := `UPDATE workspace_invitations SET status = $1, responded_at = $2
+	WHERE id = $3 AND status = 'pending' AND expires_at > now();`
+
+	tag, err := conn.Exec(ctx, query, invitation.Status, invitation.RespondedAt, invitation.Id)
+	if err != nil {
+		slog.Error("failed to update invitation", "error", err.Error())
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+// AcceptInvitation implements models.WorkspaceStore.
+func (w *WorkspaceStore) AcceptInvitation(ctx context.Context, invitation *models.Invitation, userId uuid.UUID) error {
+	memberQuery := `INSERT INTO workspace_memberships(workspace_id, user_id, role, created_at)
+	VALUES($1, $2, $3, $4);`
+
+	tx, err := db(ctx, w.conn).Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = closeInvitation(ctx, tx, invitation)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, memberQuery, invitation.Workspace.Id, userId, invitation.Role, invitation.RespondedAt)
+	if err != nil {
+		slog.Error("failed to insert membership", "error", err.Error())
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("failed to complete transactions", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// CloseInvitation implements models.WorkspaceStore.
+func (w *WorkspaceStore) CloseInvitation(ctx context.Context, invitation *models.Invitation) error {
+	return closeInvitation(ctx, db(ctx, w.conn), invitation)
+}
+
+// HasMemberWithEmail implements models.WorkspaceStore.
+func (w *WorkspaceStore) HasMemberWithEmail(ctx context.Context, workspaceId uuid.UUID, email string) (bool, error) {
+	query := `SELECT EXISTS(
+		SELECT 1 FROM workspace_memberships AS wm
+		INNER JOIN users AS u ON wm.user_id = u.id
+		WHERE wm.workspace_id = $1 AND lower(u.email) = lower($2)
+	);`
+
+	var exists bool
+	err := db(ctx, w.conn).QueryRow(ctx, query, workspaceId, email).Scan(&exists)
+	if err != nil {
+		slog.Error("failed to check membership", "error", err.Error())
+		return false, err
+	}
+
+	return exists, nil
+}
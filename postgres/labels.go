@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateLabel implements models.WorkspaceStore.
+func (w *WorkspaceStore) CreateLabel(ctx context.Context, label *models.Label) error {
+	query := `INSERT INTO labels(id, workspace_id, name, color, created_at, last_modified)
+	VALUES($1, $2, $3, $4, $5, $6);`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query, label.Id, label.WorkspaceId, label.Name, label.Color, label.CreatedAt, label.LastModified)
+	if err != nil {
+		slog.Error("failed to insert label", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+const labelColumns = `l.id, l.workspace_id, l.name, l.color, l.created_at, l.last_modified`
+
+func scanLabel(row pgx.Row, dest ...any) (models.Label, error) {
+	var label models.Label
+	err := row.Scan(append([]any{&label.Id, &label.WorkspaceId, &label.Name, &label.Color, &label.CreatedAt, &label.LastModified}, dest...)...)
+	return label, err
+}
+
+// GetLabel implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetLabel(ctx context.Context, id uuid.UUID) (*models.Label, error) {
+	query := `SELECT ` + labelColumns + ` FROM labels AS l WHERE l.id = $1;`
+
+	label, err := scanLabel(db(ctx, w.conn).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		slog.Error("failed to read label", "error", err.Error())
+		return nil, err
+	}
+
+	return &label, nil
+}
+
+// GetWorkspaceLabels implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetWorkspaceLabels(ctx context.Context, workspaceId uuid.UUID) ([]models.Label, error) {
+	query := `SELECT ` + labelColumns + ` FROM labels AS l
+	WHERE l.workspace_id = $1
+	ORDER BY lower(l.name);`
+
+	rows, err := db(ctx, w.conn).Query(ctx, query, workspaceId)
+	if err != nil {
+		slog.Error("failed to query labels", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	labels := []models.Label{}
+	for rows.Next() {
+		label, err := scanLabel(rows)
+		if err != nil {
+			slog.Error("failed to scan label", "error", err.Error())
+			return nil, err
+		}
+		labels = append(labels, label)
+	}
+
+	return labels, rows.Err()
+}
+
+// UpdateLabel implements models.WorkspaceStore.
+func (w *WorkspaceStore) UpdateLabel(ctx context.Context, label *models.Label) error {
+	query := `UPDATE labels SET name = $1, color = $2, last_modified = $3 WHERE id = $4;`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query, label.Name, label.Color, label.LastModified, label.Id)
+	if err != nil {
+		slog.Error("failed to update label", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// DeleteLabel implements models.WorkspaceStore.
+func (w *WorkspaceStore) DeleteLabel(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM labels WHERE id = $1;`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query, id)
+	if err != nil {
+		slog.Error("failed to delete label", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// AttachLabel implements models.WorkspaceStore.
+func (w *WorkspaceStore) AttachLabel(ctx context.Context, taskId, labelId uuid.UUID) error {
+	query := `INSERT INTO task_labels(task_id, label_id) VALUES($1, $2);`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query, taskId, labelId)
+	if err != nil {
+		slog.Error("failed to attach label", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// DetachLabel implements models.WorkspaceStore.
+func (w *WorkspaceStore) DetachLabel(ctx context.Context, taskId, labelId uuid.UUID) error {
+	query := `DELETE FROM task_labels WHERE task_id = $1 AND label_id = $2;`
+
+	tag, err := db(ctx, w.conn).Exec(ctx, query, taskId, labelId)
+	if err != nil {
+		slog.Error("failed to detach label", "error", err.Error())
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetLabelsForTasks implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetLabelsForTasks(ctx context.Context, taskIds []uuid.UUID) (map[uuid.UUID][]models.Label, error) {
+	query := `SELECT ` + labelColumns + `, tl.task_id
+	FROM task_labels AS tl
+	INNER JOIN labels AS l ON tl.label_id = l.id
+	WHERE tl.task_id = ANY($1)
+	ORDER BY lower(l.name);`
+
+	labels := map[uuid.UUID][]models.Label{}
+	if len(taskIds) == 0 {
+		return labels, nil
+	}
+
+	rows, err := db(ctx, w.conn).Query(ctx, query, taskIds)
+	if err != nil {
+		slog.Error("failed to query task labels", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskId uuid.UUID
+		label, err := scanLabel(rows, &taskId)
+		if err != nil {
+			slog.Error("failed to scan task label", "error", err.Error())
+			return nil, err
+		}
+		labels[taskId] = append(labels[taskId], label)
+	}
+
+	return labels, rows.Err()
+}
@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateMilestone implements models.WorkspaceStore.
+func (w *WorkspaceStore) CreateMilestone(ctx context.Context, milestone *models.Milestone) error {
+	query := `INSERT INTO milestones(id, project_id, title, description, due, created_at, last_modified)
+	VALUES($1, $2, $3, $4, NULLIF($5,'0001-01-01'::DATE), $6, $7);`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query,
+		milestone.Id,
+		milestone.ProjectId,
+		milestone.Title,
+		milestone.Description,
+		milestone.Due.Format(models.DateLayout),
+		milestone.CreatedAt,
+		milestone.LastModified,
+	)
+	if err != nil {
+		slog.Error("failed to insert milestone", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// milestoneColumns are the columns of the milestone aliased as m followed by
+// the number of its tasks and of those that are done.
+const milestoneColumns = `m.id,
+	m.project_id,
+	m.title,
+	m.description,
+	COALESCE(m.due,'0001-01-01'),
+	m.created_at,
+	m.last_modified,
+	(SELECT count(*) FROM tasks AS t WHERE t.milestone_id = m.id),
+	(SELECT count(*) FROM tasks AS t WHERE t.milestone_id = m.id AND ` + taskDoneCondition + `)`
+
+func scanMilestone(row pgx.Row) (models.Milestone, error) {
+	var milestone models.Milestone
+	err := row.Scan(&milestone.Id, &milestone.ProjectId, &milestone.Title, &milestone.Description, &milestone.Due.Time, &milestone.CreatedAt, &milestone.LastModified,
+		&milestone.Tasks, &milestone.TasksDone)
+	return milestone, err
+}
+
+// GetMilestone implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetMilestone(ctx context.Context, id uuid.UUID) (*models.Milestone, error) {
+	query := `SELECT ` + milestoneColumns + ` FROM milestones AS m WHERE m.id = $1;`
+
+	milestone, err := scanMilestone(db(ctx, w.conn).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		slog.Error("failed to read milestone", "error", err.Error())
+		return nil, err
+	}
+
+	return &milestone, nil
+}
+
+// GetProjectMilestones implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetProjectMilestones(ctx context.Context, projectId uuid.UUID) ([]models.Milestone, error) {
+	query := `SELECT ` + milestoneColumns + ` FROM milestones AS m
+	WHERE m.project_id = $1
+	ORDER BY COALESCE(m.due, 'infinity'::date), m.created_at;`
+
+	rows, err := db(ctx, w.conn).Query(ctx, query, projectId)
+	if err != nil {
+		slog.Error("failed to query milestones", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	milestones := []models.Milestone{}
+	for rows.Next() {
+		milestone, err := scanMilestone(rows)
+		if err != nil {
+			slog.Error("failed to scan milestone", "error", err.Error())
+			return nil, err
+		}
+		milestones = append(milestones, milestone)
+	}
+
+	return milestones, rows.Err()
+}
+
+// UpdateMilestone implements models.WorkspaceStore.
+func (w *WorkspaceStore) UpdateMilestone(ctx context.Context, milestone *models.Milestone) error {
+	query := `UPDATE milestones SET title = $1, description = $2, due = NULLIF($3,'0001-01-01'::DATE), last_modified = $4 WHERE id = $5;`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query, milestone.Title, milestone.Description, milestone.Due.Format(models.DateLayout), milestone.LastModified, milestone.Id)
+	if err != nil {
+		slog.Error("failed to update milestone", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// DeleteMilestone implements models.WorkspaceStore.
+func (w *WorkspaceStore) DeleteMilestone(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM milestones WHERE id = $1;`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query, id)
+	if err != nil {
+		slog.Error("failed to delete milestone", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
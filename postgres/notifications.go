@@ -0,0 +1,353 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateNotification implements models.WorkspaceStore.
+func (w *WorkspaceStore) CreateNotification(ctx context.Context, notification *models.Notification) error {
+	query := `INSERT INTO notifications(id, user_id, type, workspace_id, actor_id, entity_type, entity_id, subject, changes, in_app, email_pending, created_at)
+	VALUES($1, $2, $3, $4, NULLIF($5, '00000000-0000-0000-0000-000000000000'::uuid), $6, $7, $8, $9, $10, $11, $12);`
+
+	var actorId uuid.UUID
+	if notification.Actor != nil {
+		actorId = notification.Actor.Id
+	}
+
+	var changes map[string]models.Change
+	if len(notification.Changes) > 0 {
+		changes = notification.Changes
+	}
+
+	_, err := db(ctx, w.conn).Exec(ctx, query,
+		notification.Id,
+		notification.UserId,
+		notification.Type,
+		notification.WorkspaceId,
+		actorId,
+		notification.EntityType,
+		notification.EntityId,
+		notification.Subject,
+		changes,
+		notification.InApp,
+		notification.EmailPending,
+		notification.CreatedAt,
+	)
+	if err != nil {
+		slog.Error("failed to insert notification", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// notificationColumns are the columns of notifications aliased as n, joined
+// with their actor aliased as u.
+const notificationColumns = `n.id,
+	n.user_id,
+	n.type,
+	n.workspace_id,
+	COALESCE(n.actor_id, '00000000-0000-0000-0000-000000000000'),
+	COALESCE(u.name, ''),
+	COALESCE(u.email, ''),
+	n.entity_type,
+	n.entity_id,
+	n.subject,
+	n.changes,
+	COALESCE(n.read_at, '0001-01-01'),
+	n.created_at,
+	n.in_app,
+	n.email_pending`
+
+func scanNotification(row pgx.Row, extra ...any) (models.Notification, error) {
+	notification := models.Notification{Actor: &models.User{}}
+	dest := []any{&notification.Id, &notification.UserId, &notification.Type, &notification.WorkspaceId, &notification.Actor.Id, &notification.Actor.Name, &notification.Actor.Email,
+		&notification.EntityType, &notification.EntityId, &notification.Subject, &notification.Changes, &notification.ReadAt, &notification.CreatedAt, &notification.InApp, &notification.EmailPending}
+	err := row.Scan(append(dest, extra...)...)
+	if notification.Actor.Id == uuid.Nil {
+		notification.Actor = nil
+	}
+	return notification, err
+}
+
+// GetNotifications implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetNotifications(ctx context.Context, userId uuid.UUID, filter models.NotificationFilter) (*models.Page[models.Notification], error) {
+	q := &listQuery{
+		columns:     notificationColumns,
+		from:        "notifications AS n LEFT JOIN users AS u ON n.actor_id = u.id",
+		id:          "n.id",
+		sorts:       map[string]sortKey{"created_at": {expr: "n.created_at", cast: "timestamp"}},
+		defaultSort: "created_at",
+	}
+
+	q.where("n.user_id = %s AND n.in_app", userId)
+
+	if filter.Unread {
+		q.where("n.read_at IS NULL")
+	}
+
+	return fetchPage(ctx, db(ctx, w.conn), q, filter.ListOptions, func(rows pgx.Rows, sortValue *string, id *uuid.UUID) (models.Notification, error) {
+		return scanNotification(rows, sortValue, id)
+	})
+}
+
+// CountUnreadNotifications implements models.WorkspaceStore.
+func (w *WorkspaceStore) CountUnreadNotifications(ctx context.Context, userId uuid.UUID) (int, error) {
+	query := `SELECT count(*) FROM notifications WHERE user_id = $1 AND in_app AND read_at IS NULL;`
+
+	var count int
+	err := db(ctx, w.conn).QueryRow(ctx, query, userId).Scan(&count)
+	if err != nil {
+		slog.Error("failed to count unread notifications", "error", err.Error())
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// MarkNotificationRead implements models.WorkspaceStore.
+func (w *WorkspaceStore) MarkNotificationRead(ctx context.Context, userId, id uuid.UUID, readAt time.Time) error {
+	query := `UPDATE notifications SET read_at = COALESCE(read_at, $1) WHERE id = $2 AND user_id = $3 AND in_app;`
+
+	result, err := db(ctx, w.conn).Exec(ctx, query, readAt, id, userId)
+	if err != nil {
+		slog.Error("failed to mark notification read", "error", err.Error())
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+// MarkAllNotificationsRead implements models.WorkspaceStore.
+func (w *WorkspaceStore) MarkAllNotificationsRead(ctx context.Context, userId uuid.UUID, readAt time.Time) (int64, error) {
+	query := `UPDATE notifications SET read_at = $1 WHERE user_id = $2 AND read_at IS NULL;`
+
+	result, err := db(ctx, w.conn).Exec(ctx, query, readAt, userId)
+	if err != nil {
+		slog.Error("failed to mark notifications read", "error", err.Error())
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// GetNotificationSettings implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetNotificationSettings(ctx context.Context, userId uuid.UUID) (*models.NotificationSettings, error) {
+	query := `SELECT u.id, u.name, u.email, COALESCE(ns.email_delivery, 'immediate'), COALESCE(ns.last_digest_at, '0001-01-01')
+	FROM users AS u LEFT JOIN notification_settings AS ns ON ns.user_id = u.id
+	WHERE u.id = $1;`
+
+	var settings models.NotificationSettings
+	err := db(ctx, w.conn).QueryRow(ctx, query, userId).Scan(&settings.User.Id, &settings.User.Name, &settings.User.Email, &settings.Delivery, &settings.LastDigestAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		slog.Error("failed to read notification settings", "error", err.Error())
+		return nil, err
+	}
+
+	rows, err := db(ctx, w.conn).Query(ctx, `SELECT type, in_app, email FROM notification_preferences WHERE user_id = $1;`, userId)
+	if err != nil {
+		slog.Error("failed to query notification preferences", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	stored := map[models.NotificationType]models.NotificationPreference{}
+	for rows.Next() {
+		var pref models.NotificationPreference
+		if err := rows.Scan(&pref.Type, &pref.InApp, &pref.Email); err != nil {
+			slog.Error("failed to scan notification preference", "error", err.Error())
+			return nil, err
+		}
+		stored[pref.Type] = pref
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	settings.Preferences = make([]models.NotificationPreference, 0, len(models.NotificationTypes))
+	for _, t := range models.NotificationTypes {
+		pref, ok := stored[t]
+		if !ok {
+			pref = t.DefaultPreference()
+		}
+		settings.Preferences = append(settings.Preferences, pref)
+	}
+
+	return &settings, nil
+}
+
+// SetNotificationPreferences implements models.WorkspaceStore.
+func (w *WorkspaceStore) SetNotificationPreferences(ctx context.Context, userId uuid.UUID, prefs []models.NotificationPreference) error {
+	query := `INSERT INTO notification_preferences(user_id, type, in_app, email)
+	VALUES($1, $2, $3, $4)
+	ON CONFLICT (user_id, type) DO UPDATE SET in_app = EXCLUDED.in_app, email = EXCLUDED.email;`
+
+	tx, err := db(ctx, w.conn).Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, pref := range prefs {
+		_, err = tx.Exec(ctx, query, userId, pref.Type, pref.InApp, pref.Email)
+		if err != nil {
+			slog.Error("failed to save notification preference", "error", err.Error())
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("failed to complete transactions", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// SetEmailDelivery implements models.WorkspaceStore.
+func (w *WorkspaceStore) SetEmailDelivery(ctx context.Context, userId uuid.UUID, delivery models.EmailDelivery) error {
+	query := `INSERT INTO notification_settings(user_id, email_delivery)
+	VALUES($1, $2)
+	ON CONFLICT (user_id) DO UPDATE SET email_delivery = EXCLUDED.email_delivery;`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query, userId, delivery)
+	if err != nil {
+		slog.Error("failed to save email delivery", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetDueReminders implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetDueReminders(ctx context.Context, before time.Time, window time.Duration) ([]models.DueReminder, error) {
+	query := `SELECT t.id, t.title, t.project_id, t.due, ta.user_id, p.workspace_id
+	FROM tasks AS t
+	JOIN task_assignments AS ta ON ta.task_id = t.id
+	JOIN projects AS p ON p.id = t.project_id
+	JOIN workspace_memberships AS m ON m.workspace_id = p.workspace_id AND m.user_id = ta.user_id
+	WHERE t.due >= $1 - make_interval(secs => $3) AND t.due < $1 AND NOT ` + taskDoneCondition + `
+	AND NOT EXISTS (
+		SELECT 1 FROM notifications AS n
+		WHERE n.user_id = ta.user_id AND n.entity_id = t.id AND n.type = $2
+		AND n.created_at >= t.due - make_interval(secs => $3)
+	)
+	ORDER BY t.due;`
+
+	rows, err := db(ctx, w.conn).Query(ctx, query, before, models.NotificationTaskDueSoon, window.Seconds())
+	if err != nil {
+		slog.Error("failed to query due reminders", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	reminders := []models.DueReminder{}
+	for rows.Next() {
+		reminder := models.DueReminder{Task: models.Task{Project: &models.Project{}}}
+		err := rows.Scan(&reminder.Task.Id, &reminder.Task.Title, &reminder.Task.Project.Id, &reminder.Task.Due, &reminder.UserId, &reminder.WorkspaceId)
+		if err != nil {
+			slog.Error("failed to scan due reminder", "error", err.Error())
+			return nil, err
+		}
+		reminders = append(reminders, reminder)
+	}
+
+	return reminders, rows.Err()
+}
+
+// GetDigestRecipients implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetDigestRecipients(ctx context.Context, sentBefore time.Time) ([]uuid.UUID, error) {
+	query := `SELECT ns.user_id FROM notification_settings AS ns
+	WHERE (ns.last_digest_at IS NULL OR ns.last_digest_at < $1)
+	AND EXISTS (SELECT 1 FROM notifications AS n WHERE n.user_id = ns.user_id AND n.email_pending);`
+
+	rows, err := db(ctx, w.conn).Query(ctx, query, sentBefore)
+	if err != nil {
+		slog.Error("failed to query digest recipients", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			slog.Error("failed to scan digest recipient", "error", err.Error())
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// GetPendingEmails implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetPendingEmails(ctx context.Context, userId uuid.UUID) ([]models.Notification, error) {
+	query := `SELECT ` + notificationColumns + `
+	FROM notifications AS n LEFT JOIN users AS u ON n.actor_id = u.id
+	WHERE n.user_id = $1 AND n.email_pending
+	ORDER BY n.created_at, n.id;`
+
+	rows, err := db(ctx, w.conn).Query(ctx, query, userId)
+	if err != nil {
+		slog.Error("failed to query pending emails", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := []models.Notification{}
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			slog.Error("failed to scan notification", "error", err.Error())
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, rows.Err()
+}
+
+// MarkDigestSent implements models.WorkspaceStore.
+func (w *WorkspaceStore) MarkDigestSent(ctx context.Context, userId uuid.UUID, ids []uuid.UUID, sentAt time.Time) error {
+	tx, err := db(ctx, w.conn).Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `UPDATE notifications SET email_pending = false WHERE user_id = $1 AND id = ANY($2);`, userId, ids)
+	if err != nil {
+		slog.Error("failed to clear pending emails", "error", err.Error())
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE notification_settings SET last_digest_at = $1 WHERE user_id = $2;`, sentAt, userId)
+	if err != nil {
+		slog.Error("failed to record digest", "error", err.Error())
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("failed to complete transactions", "error", err)
+		return err
+	}
+
+	return nil
+}
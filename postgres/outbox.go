@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OutboxStore struct {
+	conn *pgxpool.Pool
+}
+
+func NewOutboxStore(conn *pgxpool.Pool) models.OutboxStore {
+	return &OutboxStore{conn: conn}
+}
+
+// EnqueueMail implements models.OutboxStore.
+func (o *OutboxStore) EnqueueMail(ctx context.Context, mail *models.OutboxMail) error {
+	query := `INSERT INTO mail_outbox(id, message, status, attempts, next_attempt_at, created_at)
+	VALUES($1, $2, $3, $4, $5, $6);`
+
+	_, err := db(ctx, o.conn).Exec(ctx, query, mail.Id, mail.Message, mail.Status, mail.Attempts, mail.NextAttemptAt, mail.CreatedAt)
+	if err != nil {
+		slog.Error("failed to enqueue mail", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// ClaimMail implements models.OutboxStore.
+func (o *OutboxStore) ClaimMail(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxMail, error) {
+	query := `UPDATE mail_outbox SET locked_until = $1 + make_interval(secs => $2)
+	WHERE id IN (
+		SELECT id FROM mail_outbox
+		WHERE status = $3 AND next_attempt_at <= $1 AND (locked_until IS NULL OR locked_until <= $1)
+		ORDER BY next_attempt_at
+		LIMIT $4
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING id, message, status, attempts, next_attempt_at, COALESCE(last_error, ''), created_at;`
+
+	now := time.Now().UTC()
+	rows, err := db(ctx, o.conn).Query(ctx, query, now, lease.Seconds(), models.MailPending, limit)
+	if err != nil {
+		slog.Error("failed to claim mail", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	mails := []models.OutboxMail{}
+	for rows.Next() {
+		var mail models.OutboxMail
+		err := rows.Scan(&mail.Id, &mail.Message, &mail.Status, &mail.Attempts, &mail.NextAttemptAt, &mail.LastError, &mail.CreatedAt)
+		if err != nil {
+			slog.Error("failed to scan mail", "error", err.Error())
+			return nil, err
+		}
+		mails = append(mails, mail)
+	}
+
+	return mails, rows.Err()
+}
+
+// MarkMailSent implements models.OutboxStore.
+func (o *OutboxStore) MarkMailSent(ctx context.Context, id uuid.UUID, sentAt time.Time) error {
+	query := `UPDATE mail_outbox SET status = $1, sent_at = $2, locked_until = NULL WHERE id = $3;`
+
+	_, err := db(ctx, o.conn).Exec(ctx, query, models.MailSent, sentAt, id)
+	if err != nil {
+		slog.Error("failed to mark mail sent", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// RetryMail implements models.OutboxStore.
+func (o *OutboxStore) RetryMail(ctx context.Context, id uuid.UUID, attempts int, nextAttemptAt time.Time, lastError string) error {
+	query := `UPDATE mail_outbox SET attempts = $1, next_attempt_at = $2, last_error = $3, locked_until = NULL WHERE id = $4;`
+
+	_, err := db(ctx, o.conn).Exec(ctx, query, attempts, nextAttemptAt, lastError, id)
+	if err != nil {
+		slog.Error("failed to reschedule mail", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// BuryMail implements models.OutboxStore.
+func (o *OutboxStore) BuryMail(ctx context.Context, id uuid.UUID, attempts int, lastError string) error {
+	query := `UPDATE mail_outbox SET status = $1, attempts = $2, last_error = $3, locked_until = NULL WHERE id = $4;`
+
+	_, err := db(ctx, o.conn).Exec(ctx, query, models.MailDead, attempts, lastError, id)
+	if err != nil {
+		slog.Error("failed to move mail to dead letters", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -14,7 +14,14 @@ func (w *WorkspaceStore) CreateProject(ctx context.Context, project *models.Proj
 	query := `INSERT INTO projects(id, name, description, workspace_id, start_date, end_date, created_at, last_modified)
 	VALUES($1, $2, $3, $4, NULLIF($5,'0001-01-01'::DATE),NULLIF($6,'0001-01-01'::DATE), $7, $8);`
 
-	_, err := w.conn.Exec(
+	tx, err := db(ctx, w.conn).Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(
 		ctx,
 		query,
 		project.Id,
@@ -31,12 +38,25 @@ func (w *WorkspaceStore) CreateProject(ctx context.Context, project *models.Proj
 		return err
 	}
 
+	for i := range project.Statuses {
+		err = insertStatus(ctx, tx, &project.Statuses[i])
+		if err != nil {
+			slog.Error("failed to insert project status", "error", err.Error())
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("failed to complete transactions", "error", err)
+		return err
+	}
+
 	return nil
 }
 
 func (w *WorkspaceStore) UpdateProject(ctx context.Context, project *models.Project) error {
 	query := `UPDATE projects SET name = $1, description = $2, start_date = NULLIF($3,'0001-01-01'::DATE), end_date = NULLIF($4,'0001-01-01'::DATE), last_modified = $5 WHERE id = $6;`
-	_, err := w.conn.Exec(
+	_, err := db(ctx, w.conn).Exec(
 		ctx,
 		query,
 		project.Name,
@@ -72,7 +92,7 @@ func (w *WorkspaceStore) GetProject(ctx context.Context, id uuid.UUID) (*models.
 	INNER JOIN workspaces AS w ON p.workspace_id = w.id
 	WHERE p.id = $1;`
 
-	row := w.conn.QueryRow(ctx, query, id)
+	row := db(ctx, w.conn).QueryRow(ctx, query, id)
 	project := &models.Project{Workspace: &models.Workspace{}}
 
 	err := row.Scan(&project.Id, &project.Name, &project.Description, &project.StartDate.Time, &project.EndDate.Time, &project.CreatedAt, &project.LastModified, &project.Workspace.Id, &project.Workspace.Name, &project.Workspace.Description, &project.Workspace.CreatedAt, &project.Workspace.LastModified)
@@ -87,48 +107,46 @@ func (w *WorkspaceStore) GetProject(ctx context.Context, id uuid.UUID) (*models.
 	return project, nil
 }
 
-func (w *WorkspaceStore) GetWorkspaceProjects(ctx context.Context, workspaceId uuid.UUID) ([]models.Project, error) {
-	query := `SELECT
-	id,
+// projectSorts lists the orderings available to project listings.
+var projectSorts = map[string]sortKey{
+	"created_at": {expr: "created_at", cast: "timestamp"},
+	"name":       {expr: "name", cast: "text"},
+	"start_date": {expr: "COALESCE(start_date, 'infinity'::date)", cast: "date"},
+	"end_date":   {expr: "COALESCE(end_date, 'infinity'::date)", cast: "date"},
+}
+
+func (w *WorkspaceStore) GetWorkspaceProjects(ctx context.Context, workspaceId uuid.UUID, filter models.ProjectFilter) (*models.Page[models.Project], error) {
+	q := &listQuery{
+		columns: `id,
 	name,
 	description,
 	COALESCE(start_date,'0001-01-01'),
 	COALESCE(end_date,'0001-01-01'),
 	created_at,
-	last_modified
-	FROM projects
-	WHERE workspace_id = $1;`
-
-	projects := []models.Project{}
-
-	rows, err := w.conn.Query(ctx, query, workspaceId)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, models.ErrNotFound
-		}
-		slog.Error("failed to fetch projects", "error", err.Error())
-		return nil, err
+	last_modified`,
+		from:        "projects",
+		id:          "id",
+		sorts:       projectSorts,
+		defaultSort: "created_at",
 	}
 
-	for rows.Next() {
-		project := models.Project{}
-
-		err := rows.Scan(&project.Id, &project.Name, &project.Description, &project.StartDate.Time, &project.EndDate.Time, &project.CreatedAt, &project.LastModified)
-		if err != nil {
-			slog.Error("failed to scan project", "error", err.Error())
-			return nil, err
-		}
+	q.where("workspace_id = %s", workspaceId)
 
-		projects = append(projects, project)
+	if filter.Search != "" {
+		q.where("(name ILIKE %[1]s OR description ILIKE %[1]s)", likePattern(filter.Search))
 	}
 
-	return projects, nil
+	return fetchPage(ctx, db(ctx, w.conn), q, filter.ListOptions, func(rows pgx.Rows, sortValue *string, id *uuid.UUID) (models.Project, error) {
+		project := models.Project{}
+		err := rows.Scan(&project.Id, &project.Name, &project.Description, &project.StartDate.Time, &project.EndDate.Time, &project.CreatedAt, &project.LastModified, sortValue, id)
+		return project, err
+	})
 }
 
 func (w *WorkspaceStore) DeleteProject(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM projects WHERE id = $1;`
 
-	_, err := w.conn.Exec(ctx, query, id)
+	_, err := db(ctx, w.conn).Exec(ctx, query, id)
 	if err != nil {
 		slog.Error("failed to delete project", "error", err.Error())
 		return err
@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// querier is the subset of a connection used to run listing queries.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// executor is the subset of a connection or transaction used to run
+// statements that return no rows.
+type executor interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// sortKey describes a column a listing can be ordered by.
+type sortKey struct {
+	expr string // SQL expression rows are ordered by; must never be NULL
+	cast string // SQL type the cursor value is converted back to
+}
+
+// cursor marks the position of the last row of a page. Sort and Desc are
+// kept so a cursor cannot be replayed against a different ordering.
+type cursor struct {
+	Sort  string    `json:"s"`
+	Desc  bool      `json:"d,omitempty"`
+	Value string    `json:"v"`
+	Id    uuid.UUID `json:"id"`
+}
+
+func (c cursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	var c cursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, fmt.Errorf("%w: malformed cursor", models.ErrInvalidQuery)
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, fmt.Errorf("%w: malformed cursor", models.ErrInvalidQuery)
+	}
+	return c, nil
+}
+
+// listQuery assembles a filtered, keyset paginated SELECT statement. Rows are
+// ordered by the selected sort key with id as the tie breaker, and two extra
+// columns, the sort value as text and the id, are appended to columns so the
+// next cursor can be built from the last row.
+type listQuery struct {
+	columns     string
+	from        string
+	id          string
+	sorts       map[string]sortKey
+	defaultSort string
+
+	conditions []string
+	args       []any
+}
+
+// arg registers a query argument and returns its placeholder.
+func (q *listQuery) arg(value any) string {
+	q.args = append(q.args, value)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+// where adds a condition; each %s verb in format is replaced by the
+// placeholder of the matching value.
+func (q *listQuery) where(format string, values ...any) {
+	placeholders := make([]any, len(values))
+	for i, v := range values {
+		placeholders[i] = q.arg(v)
+	}
+	q.conditions = append(q.conditions, fmt.Sprintf(format, placeholders...))
+}
+
+func (q *listQuery) build(opts models.ListOptions) (string, int, error) {
+	sortName := opts.Sort
+	if sortName == "" {
+		sortName = q.defaultSort
+	}
+	key, ok := q.sorts[sortName]
+	if !ok {
+		return "", 0, fmt.Errorf("%w: cannot sort by '%s'", models.ErrInvalidQuery, opts.Sort)
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = models.DefaultPageSize
+	} else if limit > models.MaxPageSize {
+		limit = models.MaxPageSize
+	}
+
+	direction, comparison := "ASC", ">"
+	if opts.Desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if opts.Cursor != "" {
+		c, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			return "", 0, err
+		}
+		if c.Sort != sortName || c.Desc != opts.Desc {
+			return "", 0, fmt.Errorf("%w: cursor does not match the requested sort", models.ErrInvalidQuery)
+		}
+		q.where(fmt.Sprintf("(%s, %s) %s (%%s::text::%s, %%s)", key.expr, q.id, comparison, key.cast), c.Value, c.Id)
+	}
+
+	var sql strings.Builder
+	fmt.Fprintf(&sql, "SELECT %s, (%s)::text, %s FROM %s", q.columns, key.expr, q.id, q.from)
+	if len(q.conditions) > 0 {
+		fmt.Fprintf(&sql, " WHERE %s", strings.Join(q.conditions, " AND "))
+	}
+	fmt.Fprintf(&sql, " ORDER BY %s %s, %s %s LIMIT %d;", key.expr, direction, q.id, direction, limit+1)
+
+	return sql.String(), limit, nil
+}
+
+// fetchPage runs q and collects a single page of results. scan must read the
+// listing's own columns followed by the two destinations it is given.
+func fetchPage[T any](ctx context.Context, conn querier, q *listQuery, opts models.ListOptions, scan func(rows pgx.Rows, sortValue *string, id *uuid.UUID) (T, error)) (*models.Page[T], error) {
+	query, limit, err := q.build(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx, query, q.args...)
+	if err != nil {
+		slog.Error("failed to run list query", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &models.Page[T]{Items: []T{}}
+	last := cursor{Sort: opts.Sort, Desc: opts.Desc}
+	if last.Sort == "" {
+		last.Sort = q.defaultSort
+	}
+
+	for rows.Next() {
+		if len(page.Items) == limit {
+			page.NextCursor = last.encode()
+			break
+		}
+
+		item, err := scan(rows, &last.Value, &last.Id)
+		if err != nil {
+			slog.Error("failed to scan list row", "error", err.Error())
+			return nil, err
+		}
+		page.Items = append(page.Items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		slog.Error("failed to read list rows", "error", err.Error())
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// likePattern builds an ILIKE pattern matching s anywhere in a value.
+func likePattern(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + replacer.Replace(s) + "%"
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestListQuery() *listQuery {
+	return &listQuery{
+		columns:     "t.id, t.title",
+		from:        "tasks AS t",
+		id:          "t.id",
+		sorts:       taskSorts,
+		defaultSort: "created_at",
+	}
+}
+
+func TestListQuery_Build(t *testing.T) {
+	q := newTestListQuery()
+	q.where("t.project_id = %s", uuid.New())
+
+	sql, limit, err := q.build(models.ListOptions{Limit: 500, Sort: "priority", Desc: true})
+	require.NoError(t, err)
+	assert.Equal(t, models.MaxPageSize, limit)
+	assert.Equal(t, "SELECT t.id, t.title, (t.priority)::text, t.id FROM tasks AS t WHERE t.project_id = $1 ORDER BY t.priority DESC, t.id DESC LIMIT 101;", sql)
+}
+
+func TestListQuery_BuildWithCursor(t *testing.T) {
+	id := uuid.New()
+	next := cursor{Sort: "due", Value: "infinity", Id: id}.encode()
+
+	q := newTestListQuery()
+	q.where("t.project_id = %s", uuid.New())
+
+	sql, limit, err := q.build(models.ListOptions{Sort: "due", Cursor: next})
+	require.NoError(t, err)
+	assert.Equal(t, models.DefaultPageSize, limit)
+	assert.Contains(t, sql, "(COALESCE(t.due, 'infinity'::timestamp), t.id) > ($2::text::timestamp, $3)")
+	assert.Equal(t, []any{q.args[0], "infinity", id}, q.args)
+}
+
+func TestListQuery_BuildInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opts models.ListOptions
+	}{
+		{name: "unknown sort", opts: models.ListOptions{Sort: "title"}},
+		{name: "malformed cursor", opts: models.ListOptions{Cursor: "not a cursor"}},
+		{name: "cursor for another sort", opts: models.ListOptions{Sort: "due", Cursor: cursor{Sort: "priority", Value: "low"}.encode()}},
+		{name: "cursor for another order", opts: models.ListOptions{Desc: true, Cursor: cursor{Sort: "created_at"}.encode()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := newTestListQuery().build(tt.opts)
+			assert.ErrorIs(t, err, models.ErrInvalidQuery)
+		})
+	}
+}
+
+func TestLikePattern(t *testing.T) {
+	assert.Equal(t, `%50\% off\_now%`, likePattern("50% off_now"))
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ts_headline does not escape the text it highlights, so matches are marked
+// with private use characters that are swapped for tags after escaping.
+const (
+	highlightStart = "\uE000"
+	highlightStop  = "\uE001"
+)
+
+// highlightOptions configure ts_headline for titles, which are highlighted
+// whole, and for the snippets taken from descriptions.
+var (
+	titleHighlight   = fmt.Sprintf("HighlightAll=true, StartSel=%s, StopSel=%s", highlightStart, highlightStop)
+	snippetHighlight = fmt.Sprintf("MaxFragments=2, MaxWords=25, MinWords=8, FragmentDelimiter=\" … \", StartSel=%s, StopSel=%s", highlightStart, highlightStop)
+)
+
+// highlight escapes a ts_headline result for HTML and wraps its matches in
+// <mark> tags.
+func highlight(s string) string {
+	s = html.EscapeString(s)
+	return strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>").Replace(s)
+}
+
+// searchFrom unions the tasks and projects matching a tsquery given as the
+// first format argument; the headline options are the second and third.
+const searchFrom = `(
+	SELECT 'task' AS type, t.id, t.project_id, p.workspace_id,
+		ts_headline('english', t.title, query, %[2]s) AS title,
+		ts_headline('english', coalesce(t.description, ''), query, %[3]s) AS snippet,
+		ts_rank(t.search, query) AS rank
+	FROM tasks AS t
+	INNER JOIN projects AS p ON t.project_id = p.id,
+	websearch_to_tsquery('english', %[1]s) AS query
+	WHERE t.search @@ query
+	UNION ALL
+	SELECT 'project' AS type, p.id, p.id, p.workspace_id,
+		ts_headline('english', p.name, query, %[2]s) AS title,
+		ts_headline('english', coalesce(p.description, ''), query, %[3]s) AS snippet,
+		ts_rank(p.search, query) AS rank
+	FROM projects AS p,
+	websearch_to_tsquery('english', %[1]s) AS query
+	WHERE p.search @@ query
+) AS r`
+
+// searchSorts lists the orderings available to searches.
+var searchSorts = map[string]sortKey{
+	"rank": {expr: "r.rank", cast: "real"},
+}
+
+// Search implements models.WorkspaceStore.
+func (w *WorkspaceStore) Search(ctx context.Context, workspaceId uuid.UUID, filter models.SearchFilter) (*models.Page[models.SearchResult], error) {
+	q := &listQuery{
+		columns:     "r.type, r.id, r.project_id, r.title, r.snippet, r.rank",
+		id:          "r.id",
+		sorts:       searchSorts,
+		defaultSort: "rank",
+	}
+	q.from = fmt.Sprintf(searchFrom, q.arg(filter.Query), q.arg(titleHighlight), q.arg(snippetHighlight))
+
+	q.where("r.workspace_id = %s", workspaceId)
+
+	if len(filter.Types) > 0 {
+		types := make([]string, len(filter.Types))
+		for i, t := range filter.Types {
+			types[i] = string(t)
+		}
+		q.where("r.type = ANY(%s)", types)
+	}
+
+	return fetchPage(ctx, db(ctx, w.conn), q, filter.ListOptions, func(rows pgx.Rows, sortValue *string, id *uuid.UUID) (models.SearchResult, error) {
+		var result models.SearchResult
+		err := rows.Scan(&result.Type, &result.Id, &result.ProjectId, &result.Title, &result.Snippet, &result.Rank, sortValue, id)
+		result.Title = highlight(result.Title)
+		result.Snippet = highlight(result.Snippet)
+		return result, err
+	})
+}
@@ -0,0 +1,30 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlight(t *testing.T) {
+	headline := "Fix " + highlightStart + "login" + highlightStop + " <script> & redirect"
+	assert.Equal(t, "Fix <mark>login</mark> &lt;script&gt; &amp; redirect", highlight(headline))
+}
+
+func TestSearchQuery(t *testing.T) {
+	q := &listQuery{
+		columns:     "r.id",
+		id:          "r.id",
+		sorts:       searchSorts,
+		defaultSort: "rank",
+	}
+	q.from = "(SELECT " + q.arg("login bug") + ") AS r"
+	q.where("r.workspace_id = %s", uuid.New())
+
+	sql, _, err := q.build(models.ListOptions{Desc: true})
+	require.NoError(t, err)
+	assert.Contains(t, sql, "FROM (SELECT $1) AS r WHERE r.workspace_id = $2 ORDER BY r.rank DESC, r.id DESC")
+}
@@ -0,0 +1,255 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateSprint implements models.WorkspaceStore.
+func (w *WorkspaceStore) CreateSprint(ctx context.Context, sprint *models.Sprint) error {
+	query := `INSERT INTO sprints(id, project_id, name, goal, start_date, end_date, state, created_at, last_modified)
+	VALUES($1, $2, $3, $4, NULLIF($5,'0001-01-01'::DATE), NULLIF($6,'0001-01-01'::DATE), $7, $8, $9);`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query,
+		sprint.Id,
+		sprint.ProjectId,
+		sprint.Name,
+		sprint.Goal,
+		sprint.StartDate.Format(models.DateLayout),
+		sprint.EndDate.Format(models.DateLayout),
+		sprint.State,
+		sprint.CreatedAt,
+		sprint.LastModified,
+	)
+	if err != nil {
+		slog.Error("failed to insert sprint", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+const sprintColumns = `id,
+	project_id,
+	name,
+	goal,
+	COALESCE(start_date,'0001-01-01'),
+	COALESCE(end_date,'0001-01-01'),
+	state,
+	COALESCE(started_at,'0001-01-01 00:00:00'),
+	COALESCE(closed_at,'0001-01-01 00:00:00'),
+	created_at,
+	last_modified`
+
+func scanSprint(row pgx.Row) (models.Sprint, error) {
+	var sprint models.Sprint
+	err := row.Scan(&sprint.Id, &sprint.ProjectId, &sprint.Name, &sprint.Goal, &sprint.StartDate.Time, &sprint.EndDate.Time, &sprint.State,
+		&sprint.StartedAt, &sprint.ClosedAt, &sprint.CreatedAt, &sprint.LastModified)
+	return sprint, err
+}
+
+// GetSprint implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetSprint(ctx context.Context, id uuid.UUID) (*models.Sprint, error) {
+	query := `SELECT ` + sprintColumns + ` FROM sprints WHERE id = $1;`
+
+	sprint, err := scanSprint(db(ctx, w.conn).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		slog.Error("failed to read sprint", "error", err.Error())
+		return nil, err
+	}
+
+	return &sprint, nil
+}
+
+// GetProjectSprints implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetProjectSprints(ctx context.Context, projectId uuid.UUID) ([]models.Sprint, error) {
+	query := `SELECT ` + sprintColumns + ` FROM sprints
+	WHERE project_id = $1
+	ORDER BY COALESCE(start_date, 'infinity'::date), created_at;`
+
+	rows, err := db(ctx, w.conn).Query(ctx, query, projectId)
+	if err != nil {
+		slog.Error("failed to query sprints", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	sprints := []models.Sprint{}
+	for rows.Next() {
+		sprint, err := scanSprint(rows)
+		if err != nil {
+			slog.Error("failed to scan sprint", "error", err.Error())
+			return nil, err
+		}
+		sprints = append(sprints, sprint)
+	}
+
+	return sprints, rows.Err()
+}
+
+// UpdateSprint implements models.WorkspaceStore.
+func (w *WorkspaceStore) UpdateSprint(ctx context.Context, sprint *models.Sprint) error {
+	query := `UPDATE sprints SET name = $1, goal = $2, start_date = NULLIF($3,'0001-01-01'::DATE), end_date = NULLIF($4,'0001-01-01'::DATE), last_modified = $5
+	WHERE id = $6;`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query,
+		sprint.Name,
+		sprint.Goal,
+		sprint.StartDate.Format(models.DateLayout),
+		sprint.EndDate.Format(models.DateLayout),
+		sprint.LastModified,
+		sprint.Id,
+	)
+	if err != nil {
+		slog.Error("failed to update sprint", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// DeleteSprint implements models.WorkspaceStore.
+func (w *WorkspaceStore) DeleteSprint(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM sprints WHERE id = $1;`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query, id)
+	if err != nil {
+		slog.Error("failed to delete sprint", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// StartSprint implements models.WorkspaceStore.
+func (w *WorkspaceStore) StartSprint(ctx context.Context, sprint *models.Sprint) error {
+	stateQuery := `UPDATE sprints SET state = $1, started_at = $2, last_modified = $2 WHERE id = $3;`
+
+	commitQuery := `INSERT INTO sprint_tasks(sprint_id, task_id, committed)
+	SELECT $1, id, true FROM tasks WHERE sprint_id = $1;`
+
+	tx, err := db(ctx, w.conn).Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, stateQuery, sprint.State, sprint.StartedAt, sprint.Id)
+	if err != nil {
+		slog.Error("failed to start sprint", "error", err.Error())
+		return err
+	}
+
+	_, err = tx.Exec(ctx, commitQuery, sprint.Id)
+	if err != nil {
+		slog.Error("failed to record sprint commitment", "error", err.Error())
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("failed to complete transactions", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// CloseSprint implements models.WorkspaceStore.
+func (w *WorkspaceStore) CloseSprint(ctx context.Context, sprint *models.Sprint, carryOverTo uuid.UUID) (int, error) {
+	resultQuery := `INSERT INTO sprint_tasks(sprint_id, task_id, committed, completed)
+	SELECT $1, t.id, false, ` + taskDoneCondition + ` FROM tasks AS t WHERE t.sprint_id = $1
+	ON CONFLICT (sprint_id, task_id) DO UPDATE SET completed = EXCLUDED.completed;`
+
+	carryQuery := `UPDATE tasks AS t SET sprint_id = NULLIF($2, '00000000-0000-0000-0000-000000000000'::uuid)
+	WHERE t.sprint_id = $1 AND NOT ` + taskDoneCondition + `;`
+
+	// only an active sprint is closed, so that concurrent closes carry over
+	// its tasks once
+	stateQuery := `UPDATE sprints SET state = $1, closed_at = $2, last_modified = $2
+	WHERE id = $3 AND state = 'active';`
+
+	tx, err := db(ctx, w.conn).Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, stateQuery, sprint.State, sprint.ClosedAt, sprint.Id)
+	if err != nil {
+		slog.Error("failed to close sprint", "error", err.Error())
+		return 0, err
+	}
+
+	if result.RowsAffected() == 0 {
+		return 0, models.ErrNotFound
+	}
+
+	_, err = tx.Exec(ctx, resultQuery, sprint.Id)
+	if err != nil {
+		slog.Error("failed to record sprint results", "error", err.Error())
+		return 0, err
+	}
+
+	tag, err := tx.Exec(ctx, carryQuery, sprint.Id, carryOverTo)
+	if err != nil {
+		slog.Error("failed to carry over sprint tasks", "error", err.Error())
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("failed to complete transactions", "error", err)
+		return 0, err
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// GetSprintSummary implements models.WorkspaceStore. The tasks of an open
+// sprint are read from the tasks table; those of a closed sprint from the
+// results recorded when it closed.
+func (w *WorkspaceStore) GetSprintSummary(ctx context.Context, sprint *models.Sprint) (*models.SprintSummary, error) {
+	query := `WITH held AS (
+		SELECT t.id AS task_id, ` + taskDoneCondition + ` AS done
+		FROM tasks AS t
+		WHERE t.sprint_id = $1 AND NOT $2
+		UNION ALL
+		SELECT task_id, completed
+		FROM sprint_tasks
+		WHERE sprint_id = $1 AND completed IS NOT NULL AND $2
+	), committed AS (
+		SELECT task_id FROM sprint_tasks WHERE sprint_id = $1 AND committed
+	)
+	SELECT
+	(SELECT count(*) FROM committed),
+	(SELECT count(*) FROM held WHERE task_id NOT IN (SELECT task_id FROM committed)),
+	(SELECT count(*) FROM committed WHERE task_id NOT IN (SELECT task_id FROM held)),
+	(SELECT count(*) FROM held WHERE done),
+	(SELECT count(*) FROM held WHERE NOT done),
+	(SELECT count(*) FROM held WHERE done AND task_id IN (SELECT task_id FROM committed));`
+
+	var summary models.SprintSummary
+	err := db(ctx, w.conn).QueryRow(ctx, query, sprint.Id, sprint.State == models.SprintClosed).Scan(
+		&summary.Committed,
+		&summary.Added,
+		&summary.Removed,
+		&summary.Completed,
+		&summary.Incomplete,
+		&summary.CommittedCompleted,
+	)
+	if err != nil {
+		slog.Error("failed to read sprint summary", "error", err.Error())
+		return nil, err
+	}
+
+	return &summary, nil
+}
@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const insertStatusQuery = `INSERT INTO project_statuses(id, project_id, key, name, category, position, created_at, last_modified)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8);`
+
+func insertStatus(ctx context.Context, conn executor, status *models.ProjectStatus) error {
+	_, err := conn.Exec(ctx, insertStatusQuery,
+		status.Id,
+		status.ProjectId,
+		status.Key,
+		status.Name,
+		status.Category,
+		status.Position,
+		status.CreatedAt,
+		status.LastModified,
+	)
+	return err
+}
+
+// CreateStatus implements models.WorkspaceStore.
+func (w *WorkspaceStore) CreateStatus(ctx context.Context, status *models.ProjectStatus) error {
+	err := insertStatus(ctx, db(ctx, w.conn), status)
+	if err != nil {
+		slog.Error("failed to insert status", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+const statusColumns = `id, project_id, key, name, category, position, created_at, last_modified`
+
+func scanStatus(row pgx.Row) (models.ProjectStatus, error) {
+	var status models.ProjectStatus
+	err := row.Scan(&status.Id, &status.ProjectId, &status.Key, &status.Name, &status.Category, &status.Position, &status.CreatedAt, &status.LastModified)
+	return status, err
+}
+
+// GetStatus implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetStatus(ctx context.Context, id uuid.UUID) (*models.ProjectStatus, error) {
+	query := `SELECT ` + statusColumns + ` FROM project_statuses WHERE id = $1;`
+
+	status, err := scanStatus(db(ctx, w.conn).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		slog.Error("failed to read status", "error", err.Error())
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// GetProjectStatuses implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetProjectStatuses(ctx context.Context, projectId uuid.UUID) ([]models.ProjectStatus, error) {
+	query := `SELECT ` + statusColumns + ` FROM project_statuses
+	WHERE project_id = $1
+	ORDER BY position, created_at;`
+
+	rows, err := db(ctx, w.conn).Query(ctx, query, projectId)
+	if err != nil {
+		slog.Error("failed to query statuses", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	statuses := []models.ProjectStatus{}
+	for rows.Next() {
+		status, err := scanStatus(rows)
+		if err != nil {
+			slog.Error("failed to scan status", "error", err.Error())
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, rows.Err()
+}
+
+// UpdateStatus implements models.WorkspaceStore.
+func (w *WorkspaceStore) UpdateStatus(ctx context.Context, status *models.ProjectStatus) error {
+	query := `UPDATE project_statuses SET name = $1, category = $2, position = $3, last_modified = $4 WHERE id = $5;`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query, status.Name, status.Category, status.Position, status.LastModified, status.Id)
+	if err != nil {
+		slog.Error("failed to update status", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// DeleteStatus implements models.WorkspaceStore.
+func (w *WorkspaceStore) DeleteStatus(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM project_statuses WHERE id = $1;`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query, id)
+	if err != nil {
+		slog.Error("failed to delete status", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetStatusTransitions implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetStatusTransitions(ctx context.Context, projectId uuid.UUID) ([]models.StatusTransition, error) {
+	query := `SELECT from_status, to_status FROM status_transitions
+	WHERE project_id = $1
+	ORDER BY from_status, to_status;`
+
+	rows, err := db(ctx, w.conn).Query(ctx, query, projectId)
+	if err != nil {
+		slog.Error("failed to query status transitions", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	transitions := []models.StatusTransition{}
+	for rows.Next() {
+		var transition models.StatusTransition
+		if err := rows.Scan(&transition.From, &transition.To); err != nil {
+			slog.Error("failed to scan status transition", "error", err.Error())
+			return nil, err
+		}
+		transitions = append(transitions, transition)
+	}
+
+	return transitions, rows.Err()
+}
+
+// SetStatusTransitions implements models.WorkspaceStore.
+func (w *WorkspaceStore) SetStatusTransitions(ctx context.Context, projectId uuid.UUID, transitions []models.StatusTransition) error {
+	deleteQuery := `DELETE FROM status_transitions WHERE project_id = $1;`
+
+	insertQuery := `INSERT INTO status_transitions(project_id, from_status, to_status) VALUES($1, $2, $3);`
+
+	tx, err := db(ctx, w.conn).Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, deleteQuery, projectId)
+	if err != nil {
+		slog.Error("failed to clear status transitions", "error", err.Error())
+		return err
+	}
+
+	for _, transition := range transitions {
+		_, err = tx.Exec(ctx, insertQuery, projectId, transition.From, transition.To)
+		if err != nil {
+			slog.Error("failed to insert status transition", "error", err.Error())
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("failed to complete transactions", "error", err)
+		return err
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/primekobie/hazel/models"
 	"github.com/google/uuid"
@@ -12,19 +13,25 @@ import (
 
 // CreateTask implements models.WorkspaceStore.
 func (w *WorkspaceStore) CreateTask(ctx context.Context, task *models.Task) error {
-	query := `INSERT INTO tasks(id, title, description, project_id, status, priority, due, created_at, last_modified)
-	VALUES($1, $2, $3, $4, $5, $6, NULLIF($7,'0001-01-01 00:00:00'::TIMESTAMP), $8, $9);`
+	query := `INSERT INTO tasks(id, title, description, project_id, parent_id, sprint_id, milestone_id, status, rank, priority, due, estimate, created_at, last_modified)
+	VALUES($1, $2, $3, $4, NULLIF($5, '00000000-0000-0000-0000-000000000000'::uuid), NULLIF($6, '00000000-0000-0000-0000-000000000000'::uuid),
+	NULLIF($7, '00000000-0000-0000-0000-000000000000'::uuid), $8, $9, $10, NULLIF($11,'0001-01-01 00:00:00'::TIMESTAMP), $12, $13, $14);`
 
-	_, err := w.conn.Exec(
+	_, err := db(ctx, w.conn).Exec(
 		ctx,
 		query,
 		task.Id,
 		task.Title,
 		task.Description,
 		task.Project.Id,
+		task.ParentId,
+		task.SprintId,
+		task.MilestoneId,
 		task.Status,
+		task.Rank,
 		task.Priority,
 		task.Due,
+		task.Estimate,
 		task.CreatedAt,
 		task.LastModified,
 	)
@@ -40,7 +47,7 @@ func (w *WorkspaceStore) CreateTask(ctx context.Context, task *models.Task) erro
 func (w *WorkspaceStore) DeleteTask(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM tasks WHERE id = $1;`
 
-	_, err := w.conn.Exec(ctx, query, id)
+	_, err := db(ctx, w.conn).Exec(ctx, query, id)
 	if err != nil {
 		slog.Error("failed to delete task", "error", err.Error())
 		return err
@@ -49,17 +56,54 @@ func (w *WorkspaceStore) DeleteTask(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// taskCategoryColumn is the category of the status of the task aliased as t.
+const taskCategoryColumn = `(SELECT ps.category FROM project_statuses AS ps WHERE ps.project_id = t.project_id AND ps.key = t.status)`
+
+// taskDoneCondition holds for tasks aliased as t whose status is in the done
+// category.
+const taskDoneCondition = taskCategoryColumn + ` = 'done'`
+
+// taskProgressColumns counts the direct subtasks and checklist items of the
+// task aliased as t.
+const taskProgressColumns = `(SELECT count(*) FROM tasks AS s WHERE s.parent_id = t.id),
+	(SELECT count(*) FROM tasks AS s
+		INNER JOIN project_statuses AS ss ON ss.project_id = s.project_id AND ss.key = s.status
+		WHERE s.parent_id = t.id AND ss.category = 'done'),
+	(SELECT count(*) FROM checklist_items AS ci WHERE ci.task_id = t.id),
+	(SELECT count(*) FROM checklist_items AS ci WHERE ci.task_id = t.id AND ci.done)`
+
+// taskBlockedColumn reports whether the task aliased as t has a blocker that
+// is not done.
+const taskBlockedColumn = `EXISTS (
+		SELECT 1 FROM task_dependencies AS d
+		INNER JOIN tasks AS b ON d.blocker_id = b.id
+		INNER JOIN project_statuses AS bs ON bs.project_id = b.project_id AND bs.key = b.status
+		WHERE d.task_id = t.id AND bs.category <> 'done'
+	)`
+
+// taskTimeSpentColumn sums the time logged on the task aliased as t.
+const taskTimeSpentColumn = `(SELECT COALESCE(sum(te.duration), 0)::bigint FROM time_entries AS te WHERE te.task_id = t.id)`
+
 // GetTask implements models.WorkspaceStore.
 func (w *WorkspaceStore) GetTask(ctx context.Context, id uuid.UUID) (*models.Task, error) {
 	query := `SELECT
 	t.id,
 	t.title,
 	t.description,
+	COALESCE(t.parent_id, '00000000-0000-0000-0000-000000000000'),
+	COALESCE(t.sprint_id, '00000000-0000-0000-0000-000000000000'),
+	COALESCE(t.milestone_id, '00000000-0000-0000-0000-000000000000'),
 	t.status,
+	` + taskCategoryColumn + `,
+	t.rank,
 	t.priority,
-	t.due,
+	COALESCE(t.due,'0001-01-01 00:00:00'),
+	t.estimate,
 	t.created_at,
 	t.last_modified,
+	` + taskBlockedColumn + `,
+	` + taskTimeSpentColumn + `,
+	` + taskProgressColumns + `,
 	p.id,
 	p.name,
 	p.description,
@@ -71,9 +115,14 @@ func (w *WorkspaceStore) GetTask(ctx context.Context, id uuid.UUID) (*models.Tas
 
 	task := &models.Task{Project: &models.Project{}}
 
-	row := w.conn.QueryRow(ctx, query, id)
-	err := row.Scan(&task.Id, &task.Title, &task.Description, &task.Status, &task.Priority, &task.Due, &task.CreatedAt, &task.LastModified, &task.Project.Id, &task.Project.Name, &task.Project.Description, &task.Project.CreatedAt, &task.Project.LastModified)
+	row := db(ctx, w.conn).QueryRow(ctx, query, id)
+	err := row.Scan(&task.Id, &task.Title, &task.Description, &task.ParentId, &task.SprintId, &task.MilestoneId, &task.Status, &task.Category, &task.Rank, &task.Priority, &task.Due, &task.Estimate, &task.CreatedAt, &task.LastModified, &task.Blocked, &task.TimeSpent,
+		&task.Progress.Subtasks, &task.Progress.SubtasksDone, &task.Progress.Checklist, &task.Progress.ChecklistDone,
+		&task.Project.Id, &task.Project.Name, &task.Project.Description, &task.Project.CreatedAt, &task.Project.LastModified)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
 		slog.Error("failed to scan task", "error", err.Error())
 		return nil, err
 	}
@@ -81,50 +130,140 @@ func (w *WorkspaceStore) GetTask(ctx context.Context, id uuid.UUID) (*models.Tas
 	return task, nil
 }
 
+// taskSorts lists the orderings available to task listings.
+var taskSorts = map[string]sortKey{
+	"rank":       {expr: "t.rank", cast: "text"},
+	"created_at": {expr: "t.created_at", cast: "timestamp"},
+	"due":        {expr: "COALESCE(t.due, 'infinity'::timestamp)", cast: "timestamp"},
+	"priority":   {expr: "t.priority", cast: "task_priority"},
+}
+
 // GetTasksForProject implements models.WorkspaceStore.
-func (w *WorkspaceStore) GetTasksForProject(ctx context.Context, projectId uuid.UUID) ([]models.Task, error) {
-	query := `SELECT
-	id,
-	title,
-	description,
-	status,
-	priority,
-	COALESCE(due,'0001-01-01 00:00:00'),
-	created_at,
-	last_modified
-	FROM tasks
-	WHERE project_id = $1;`
+func (w *WorkspaceStore) GetTasksForProject(ctx context.Context, projectId uuid.UUID, filter models.TaskFilter) (*models.Page[models.Task], error) {
+	q := &listQuery{
+		columns: taskListColumns,
+		from:        "tasks AS t",
+		id:          "t.id",
+		sorts:       taskSorts,
+		defaultSort: "rank",
+	}
 
-	tasks := []models.Task{}
+	q.where("t.project_id = %s", projectId)
+	applyTaskFilter(q, filter)
 
-	rows, err := w.conn.Query(ctx, query, projectId)
-	if err != nil {
-		slog.Error("failed to query tasks", "error", err.Error())
-		return nil, err
+	return fetchPage(ctx, db(ctx, w.conn), q, filter.ListOptions, func(rows pgx.Rows, sortValue *string, id *uuid.UUID) (models.Task, error) {
+		return scanListedTask(rows, sortValue, id)
+	})
+}
+
+// GetTasksForWorkspace implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetTasksForWorkspace(ctx context.Context, workspaceId uuid.UUID, filter models.TaskFilter) (*models.Page[models.Task], error) {
+	q := &listQuery{
+		columns:     taskListColumns,
+		from:        "tasks AS t",
+		id:          "t.id",
+		sorts:       taskSorts,
+		defaultSort: "rank",
 	}
 
-	for rows.Next() {
-		var task models.Task
+	q.where("t.project_id IN (SELECT p.id FROM projects AS p WHERE p.workspace_id = %s)", workspaceId)
+	applyTaskFilter(q, filter)
 
-		err := rows.Scan(&task.Id, &task.Title, &task.Description, &task.Status, &task.Priority, &task.Due, &task.CreatedAt, &task.LastModified)
-		if err != nil {
-			slog.Error("failed to scan task", "error", err.Error())
-			return nil, err
+	return fetchPage(ctx, db(ctx, w.conn), q, filter.ListOptions, func(rows pgx.Rows, sortValue *string, id *uuid.UUID) (models.Task, error) {
+		return scanListedTask(rows, sortValue, id)
+	})
+}
+
+// taskListColumns are the columns of tasks returned in listings.
+const taskListColumns = `t.id,
+	t.title,
+	t.description,
+	COALESCE(t.parent_id, '00000000-0000-0000-0000-000000000000'),
+	COALESCE(t.sprint_id, '00000000-0000-0000-0000-000000000000'),
+	COALESCE(t.milestone_id, '00000000-0000-0000-0000-000000000000'),
+	t.status,
+	` + taskCategoryColumn + `,
+	t.rank,
+	t.priority,
+	COALESCE(t.due,'0001-01-01 00:00:00'),
+	t.estimate,
+	t.created_at,
+	t.last_modified,
+	` + taskBlockedColumn + `,
+	` + taskTimeSpentColumn + `,
+	` + taskProgressColumns
+
+// scanListedTask scans taskListColumns followed by any extra destinations.
+func scanListedTask(row pgx.Row, dest ...any) (models.Task, error) {
+	var task models.Task
+	err := row.Scan(append([]any{&task.Id, &task.Title, &task.Description, &task.ParentId, &task.SprintId, &task.MilestoneId, &task.Status, &task.Category, &task.Rank, &task.Priority, &task.Due, &task.Estimate, &task.CreatedAt, &task.LastModified, &task.Blocked, &task.TimeSpent,
+		&task.Progress.Subtasks, &task.Progress.SubtasksDone, &task.Progress.Checklist, &task.Progress.ChecklistDone}, dest...)...)
+	return task, err
+}
+
+// applyTaskFilter adds the conditions of filter to a listing over tasks aliased as t.
+func applyTaskFilter(q *listQuery, filter models.TaskFilter) {
+	if len(filter.Status) > 0 {
+		statuses := make([]string, len(filter.Status))
+		for i, status := range filter.Status {
+			statuses[i] = string(status)
 		}
+		q.where("t.status = ANY(%s)", statuses)
+	}
 
-		tasks = append(tasks, task)
+	if len(filter.Priority) > 0 {
+		priorities := make([]string, len(filter.Priority))
+		for i, priority := range filter.Priority {
+			priorities[i] = string(priority)
+		}
+		q.where("t.priority::text = ANY(%s)", priorities)
+	}
+
+	if filter.Assignee != uuid.Nil {
+		q.where("EXISTS (SELECT 1 FROM task_assignments AS ta WHERE ta.task_id = t.id AND ta.user_id = %s)", filter.Assignee)
+	}
+
+	if len(filter.Labels) > 0 {
+		q.where("EXISTS (SELECT 1 FROM task_labels AS tl WHERE tl.task_id = t.id AND tl.label_id = ANY(%s))", filter.Labels)
+	}
+
+	if filter.Sprint != uuid.Nil {
+		q.where("t.sprint_id = %s", filter.Sprint)
+	} else if filter.Backlog {
+		q.where("t.sprint_id IS NULL")
+	}
+
+	if filter.Milestone != uuid.Nil {
+		q.where("t.milestone_id = %s", filter.Milestone)
+	}
+
+	if !filter.DueFrom.IsZero() {
+		q.where("t.due >= %s", filter.DueFrom)
+	}
+
+	if !filter.DueTo.IsZero() {
+		q.where("t.due < %s", filter.DueTo)
+	}
+
+	if filter.Overdue {
+		q.where("t.due < %s AND "+taskCategoryColumn+" <> 'done'", time.Now().UTC())
 	}
 
-	return tasks, nil
+	if filter.Search != "" {
+		q.where("(t.title ILIKE %[1]s OR t.description ILIKE %[1]s)", likePattern(filter.Search))
+	}
 }
 
 // UpdateTask implements models.WorkspaceStore.
 func (w *WorkspaceStore) UpdateTask(ctx context.Context, task *models.Task) error {
 	query := `UPDATE tasks
-	SET title = $1, description = $2, status = $3, priority = $4, due = $5, last_modified = $6
-	WHERE id = $7;`
+	SET title = $1, description = $2, status = $3, priority = $4, due = NULLIF($5,'0001-01-01 00:00:00'::TIMESTAMP), last_modified = $6,
+	parent_id = NULLIF($7, '00000000-0000-0000-0000-000000000000'::uuid), rank = $8,
+	sprint_id = NULLIF($9, '00000000-0000-0000-0000-000000000000'::uuid), milestone_id = NULLIF($10, '00000000-0000-0000-0000-000000000000'::uuid),
+	estimate = $11
+	WHERE id = $12;`
 
-	_, err := w.conn.Exec(ctx, query, task.Title, task.Description, task.Status, task.Priority, task.Due, task.LastModified, task.Id)
+	_, err := db(ctx, w.conn).Exec(ctx, query, task.Title, task.Description, task.Status, task.Priority, task.Due, task.LastModified, task.ParentId, task.Rank, task.SprintId, task.MilestoneId, task.Estimate, task.Id)
 	if err != nil {
 		slog.Error("failed to scan task", "error", err.Error())
 		return err
@@ -138,7 +277,7 @@ func (w *WorkspaceStore) AssignTask(ctx context.Context, taskId uuid.UUID, userI
 	query := `INSERT INTO task_assignments(task_id, user_id)
 	VALUES($1, $2)`
 
-	_, err := w.conn.Exec(ctx, query, taskId, userId)
+	_, err := db(ctx, w.conn).Exec(ctx, query, taskId, userId)
 	if err != nil {
 		slog.Error("failed to assign task to user", "error", err.Error())
 		return err
@@ -162,7 +301,7 @@ func (w *WorkspaceStore) GetAssignedUsers(ctx context.Context, taskId uuid.UUID)
 
 	users := []models.User{}
 
-	rows, err := w.conn.Query(ctx, query, taskId)
+	rows, err := db(ctx, w.conn).Query(ctx, query, taskId)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, models.ErrNotFound
@@ -190,7 +329,7 @@ func (w *WorkspaceStore) GetAssignedUsers(ctx context.Context, taskId uuid.UUID)
 func (w *WorkspaceStore) UnassignTask(ctx context.Context, taskId uuid.UUID, userId uuid.UUID) error {
 	query := `DELETE FROM task_assignments WHERE task_id = $1 AND user_id = $2;`
 
-	_, err := w.conn.Exec(ctx, query, taskId, userId)
+	_, err := db(ctx, w.conn).Exec(ctx, query, taskId, userId)
 	if err != nil {
 		slog.Error("failed to delete task assignment", "error", err.Error())
 		return err
@@ -198,3 +337,140 @@ func (w *WorkspaceStore) UnassignTask(ctx context.Context, taskId uuid.UUID, use
 
 	return nil
 }
+
+// queryTasks runs a query selecting taskListColumns.
+func (w *WorkspaceStore) queryTasks(ctx context.Context, query string, args ...any) ([]models.Task, error) {
+	rows, err := db(ctx, w.conn).Query(ctx, query, args...)
+	if err != nil {
+		slog.Error("failed to query tasks", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := []models.Task{}
+	for rows.Next() {
+		task, err := scanListedTask(rows)
+		if err != nil {
+			slog.Error("failed to scan task", "error", err.Error())
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, rows.Err()
+}
+
+// GetSubtasks implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetSubtasks(ctx context.Context, parentId uuid.UUID) ([]models.Task, error) {
+	query := `SELECT ` + taskListColumns + `
+	FROM tasks AS t
+	WHERE t.parent_id = $1
+	ORDER BY t.created_at, t.id;`
+
+	return w.queryTasks(ctx, query, parentId)
+}
+
+// GetTaskAncestors implements models.WorkspaceStore. The walk stops after
+// more levels than a valid hierarchy can have so that it always ends.
+func (w *WorkspaceStore) GetTaskAncestors(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	query := `WITH RECURSIVE ancestors(id, parent_id, depth) AS (
+		SELECT id, parent_id, 1 FROM tasks WHERE id = $1
+		UNION ALL
+		SELECT t.id, t.parent_id, a.depth + 1
+		FROM tasks AS t
+		INNER JOIN ancestors AS a ON t.id = a.parent_id
+		WHERE a.depth <= $2
+	)
+	SELECT id FROM ancestors ORDER BY depth;`
+
+	rows, err := db(ctx, w.conn).Query(ctx, query, id, models.MaxTaskDepth)
+	if err != nil {
+		slog.Error("failed to query task ancestors", "error", err.Error())
+		return nil, err
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		slog.Error("failed to scan task ancestors", "error", err.Error())
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return nil, models.ErrNotFound
+	}
+
+	return ids, nil
+}
+
+// GetSubtaskDepth implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetSubtaskDepth(ctx context.Context, id uuid.UUID) (int, error) {
+	query := `WITH RECURSIVE descendants(id, depth) AS (
+		SELECT id, 1 FROM tasks WHERE parent_id = $1
+		UNION ALL
+		SELECT t.id, d.depth + 1
+		FROM tasks AS t
+		INNER JOIN descendants AS d ON t.parent_id = d.id
+		WHERE d.depth <= $2
+	)
+	SELECT COALESCE(max(depth), 0) FROM descendants;`
+
+	var depth int
+	err := db(ctx, w.conn).QueryRow(ctx, query, id, models.MaxTaskDepth).Scan(&depth)
+	if err != nil {
+		slog.Error("failed to query subtask depth", "error", err.Error())
+		return 0, err
+	}
+
+	return depth, nil
+}
+
+// GetColumnRanks implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetColumnRanks(ctx context.Context, projectId uuid.UUID, status models.TaskStatus) ([]models.TaskRank, error) {
+	// an advisory lock also covers tasks that are about to enter the column,
+	// which row locks on its current tasks would not
+	lockQuery := `SELECT pg_advisory_xact_lock(hashtextextended($1::text || '/' || $2, 0));`
+
+	query := `SELECT id, rank FROM tasks
+	WHERE project_id = $1 AND status = $2
+	ORDER BY rank, id;`
+
+	_, err := db(ctx, w.conn).Exec(ctx, lockQuery, projectId, status)
+	if err != nil {
+		slog.Error("failed to lock task column", "error", err.Error())
+		return nil, err
+	}
+
+	rows, err := db(ctx, w.conn).Query(ctx, query, projectId, status)
+	if err != nil {
+		slog.Error("failed to query task ranks", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	ranks := []models.TaskRank{}
+	for rows.Next() {
+		var rank models.TaskRank
+		if err := rows.Scan(&rank.Id, &rank.Rank); err != nil {
+			slog.Error("failed to scan task rank", "error", err.Error())
+			return nil, err
+		}
+		ranks = append(ranks, rank)
+	}
+
+	return ranks, rows.Err()
+}
+
+// SetTaskRanks implements models.WorkspaceStore.
+func (w *WorkspaceStore) SetTaskRanks(ctx context.Context, ranks []models.TaskRank) error {
+	query := `UPDATE tasks SET rank = $1 WHERE id = $2;`
+
+	for _, rank := range ranks {
+		_, err := db(ctx, w.conn).Exec(ctx, query, rank.Rank, rank.Id)
+		if err != nil {
+			slog.Error("failed to update task rank", "error", err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
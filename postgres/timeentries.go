@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateTimeEntry implements models.WorkspaceStore.
+func (w *WorkspaceStore) CreateTimeEntry(ctx context.Context, entry *models.TimeEntry) error {
+	query := `INSERT INTO time_entries(id, task_id, user_id, note, started_at, ended_at, duration, created_at, last_modified)
+	VALUES($1, $2, $3, $4, $5, NULLIF($6,'0001-01-01 00:00:00'::TIMESTAMP), $7, $8, $9);`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query,
+		entry.Id,
+		entry.TaskId,
+		entry.UserId,
+		entry.Note,
+		entry.StartedAt,
+		entry.EndedAt,
+		entry.Duration,
+		entry.CreatedAt,
+		entry.LastModified,
+	)
+	if err != nil {
+		slog.Error("failed to insert time entry", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+const timeEntryColumns = `id, task_id, user_id, note, started_at, COALESCE(ended_at,'0001-01-01 00:00:00'), duration, created_at, last_modified`
+
+func scanTimeEntry(row pgx.Row) (models.TimeEntry, error) {
+	var entry models.TimeEntry
+	err := row.Scan(&entry.Id, &entry.TaskId, &entry.UserId, &entry.Note, &entry.StartedAt, &entry.EndedAt, &entry.Duration, &entry.CreatedAt, &entry.LastModified)
+	return entry, err
+}
+
+func (w *WorkspaceStore) getTimeEntry(ctx context.Context, query string, args ...any) (*models.TimeEntry, error) {
+	entry, err := scanTimeEntry(db(ctx, w.conn).QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		slog.Error("failed to read time entry", "error", err.Error())
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// GetTimeEntry implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetTimeEntry(ctx context.Context, id uuid.UUID) (*models.TimeEntry, error) {
+	query := `SELECT ` + timeEntryColumns + ` FROM time_entries WHERE id = $1;`
+	return w.getTimeEntry(ctx, query, id)
+}
+
+// GetRunningTimer implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetRunningTimer(ctx context.Context, userId uuid.UUID) (*models.TimeEntry, error) {
+	query := `SELECT ` + timeEntryColumns + ` FROM time_entries WHERE user_id = $1 AND ended_at IS NULL;`
+	return w.getTimeEntry(ctx, query, userId)
+}
+
+// GetTaskTimeEntries implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetTaskTimeEntries(ctx context.Context, taskId uuid.UUID) ([]models.TimeEntry, error) {
+	query := `SELECT ` + timeEntryColumns + ` FROM time_entries
+	WHERE task_id = $1
+	ORDER BY started_at DESC, id;`
+
+	rows, err := db(ctx, w.conn).Query(ctx, query, taskId)
+	if err != nil {
+		slog.Error("failed to query time entries", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []models.TimeEntry{}
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			slog.Error("failed to scan time entry", "error", err.Error())
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// UpdateTimeEntry implements models.WorkspaceStore.
+func (w *WorkspaceStore) UpdateTimeEntry(ctx context.Context, entry *models.TimeEntry) error {
+	query := `UPDATE time_entries
+	SET note = $1, started_at = $2, ended_at = NULLIF($3,'0001-01-01 00:00:00'::TIMESTAMP), duration = $4, last_modified = $5
+	WHERE id = $6;`
+
+	result, err := db(ctx, w.conn).Exec(ctx, query, entry.Note, entry.StartedAt, entry.EndedAt, entry.Duration, entry.LastModified, entry.Id)
+	if err != nil {
+		slog.Error("failed to update time entry", "error", err.Error())
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteTimeEntry implements models.WorkspaceStore.
+func (w *WorkspaceStore) DeleteTimeEntry(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM time_entries WHERE id = $1;`
+
+	result, err := db(ctx, w.conn).Exec(ctx, query, id)
+	if err != nil {
+		slog.Error("failed to delete time entry", "error", err.Error())
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetTimeLogs implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetTimeLogs(ctx context.Context, filter models.TimeReportFilter) ([]models.TimeLog, error) {
+	conditions := []string{"te.ended_at IS NOT NULL"}
+	args := []any{}
+	where := func(format string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, fmt.Sprintf("$%d", len(args))))
+	}
+
+	if filter.WorkspaceId != uuid.Nil {
+		where("p.workspace_id = %s", filter.WorkspaceId)
+	}
+	if filter.ProjectId != uuid.Nil {
+		where("p.id = %s", filter.ProjectId)
+	}
+	if filter.UserId != uuid.Nil {
+		where("te.user_id = %s", filter.UserId)
+	}
+	if !filter.From.IsZero() {
+		where("te.started_at >= %s", filter.From)
+	}
+	if !filter.To.IsZero() {
+		where("te.started_at < %s", filter.To)
+	}
+
+	query := `SELECT p.id, p.name, u.id, u.name, te.started_at::date AS day, sum(te.duration)::bigint
+	FROM time_entries AS te
+	INNER JOIN tasks AS t ON te.task_id = t.id
+	INNER JOIN projects AS p ON t.project_id = p.id
+	INNER JOIN users AS u ON te.user_id = u.id
+	WHERE ` + strings.Join(conditions, " AND ") + `
+	GROUP BY p.id, p.name, u.id, u.name, day
+	ORDER BY day, p.name, u.name;`
+
+	rows, err := db(ctx, w.conn).Query(ctx, query, args...)
+	if err != nil {
+		slog.Error("failed to query time logs", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	logs := []models.TimeLog{}
+	for rows.Next() {
+		var log models.TimeLog
+		err := rows.Scan(&log.ProjectId, &log.ProjectName, &log.UserId, &log.UserName, &log.Day.Time, &log.Duration)
+		if err != nil {
+			slog.Error("failed to scan time log", "error", err.Error())
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+
+	return logs, rows.Err()
+}
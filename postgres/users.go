@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/primekobie/hazel/models"
 	"github.com/google/uuid"
@@ -22,13 +23,18 @@ func NewUserStore(conn *pgxpool.Pool) models.UserStore {
 	}
 }
 
+// WithinTx implements models.UserStore.
+func (u *UserStore) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTx(ctx, u.conn, fn)
+}
+
 // InsertUser implements models.UserStore.
 func (u *UserStore) InsertUser(ctx context.Context, user *models.User) error {
 	query := `
 		INSERT INTO users (id, name, email, password_hash, profile_photo, created_at, last_modified, verified)
 		VALUES ($1, NULLIF($2,''), $3, $4, $5, $6, $7, $8);`
 
-	_, err := u.conn.Exec(ctx, query,
+	_, err := db(ctx, u.conn).Exec(ctx, query,
 		user.Id,
 		user.Name,
 		user.Email,
@@ -52,7 +58,7 @@ func (u *UserStore) InsertUser(ctx context.Context, user *models.User) error {
 func (u *UserStore) DeleteUser(ctx context.Context, id string) error {
 	query := `DELETE FROM users WHERE id = $1;`
 
-	result, err := u.conn.Exec(ctx, query, id)
+	result, err := db(ctx, u.conn).Exec(ctx, query, id)
 	if err != nil {
 		slog.Error("failed delete user", "error", err)
 		return err
@@ -67,17 +73,18 @@ func (u *UserStore) DeleteUser(ctx context.Context, id string) error {
 // GetUser implements models.UserStore.
 func (u *UserStore) GetUser(ctx context.Context, id uuid.UUID) (models.User, error) {
 	query := `
-		SELECT id, name, email, password_hash, profile_photo, created_at, last_modified, verified 
+		SELECT id, name, email, password_hash, profile_photo, photo_key, created_at, last_modified, verified 
 		FROM users 
 		WHERE id = $1;`
 
 	var user models.User
-	err := u.conn.QueryRow(ctx, query, id).Scan(
+	err := db(ctx, u.conn).QueryRow(ctx, query, id).Scan(
 		&user.Id,
 		&user.Name,
 		&user.Email,
 		&user.PasswordHash,
 		&user.ProfilePhoto,
+		&user.PhotoKey,
 		&user.CreatedAt,
 		&user.LastModifed,
 		&user.Verified,
@@ -93,17 +100,18 @@ func (u *UserStore) GetUser(ctx context.Context, id uuid.UUID) (models.User, err
 // GetUserByMail implements models.UserStore.
 func (u *UserStore) GetUserByMail(ctx context.Context, email string) (models.User, error) {
 	query := `
-		SELECT id, name, email, password_hash, profile_photo, created_at, last_modified, verified 
+		SELECT id, name, email, password_hash, profile_photo, photo_key, created_at, last_modified, verified 
 		FROM users 
 		WHERE email = $1;`
 
 	var user models.User
-	err := u.conn.QueryRow(ctx, query, email).Scan(
+	err := db(ctx, u.conn).QueryRow(ctx, query, email).Scan(
 		&user.Id,
 		&user.Name,
 		&user.Email,
 		&user.PasswordHash,
 		&user.ProfilePhoto,
+		&user.PhotoKey,
 		&user.CreatedAt,
 		&user.LastModifed,
 		&user.Verified,
@@ -120,14 +128,15 @@ func (u *UserStore) GetUserByMail(ctx context.Context, email string) (models.Use
 func (u *UserStore) UpdateUser(ctx context.Context, user *models.User) error {
 	query := `
 		UPDATE users 
-		SET name = $1, email = $2, password_hash = $3, profile_photo = $4, last_modified = $5, verified = $6
-		WHERE id = $7;`
+		SET name = $1, email = $2, password_hash = $3, profile_photo = $4, photo_key = $5, last_modified = $6, verified = $7
+		WHERE id = $8;`
 
-	result, err := u.conn.Exec(ctx, query,
+	result, err := db(ctx, u.conn).Exec(ctx, query,
 		user.Name,
 		user.Email,
 		user.PasswordHash,
 		user.ProfilePhoto,
+		user.PhotoKey,
 		user.LastModifed,
 		user.Verified,
 		user.Id,
@@ -146,10 +155,14 @@ func (u *UserStore) UpdateUser(ctx context.Context, user *models.User) error {
 
 // InsertToken implements models.TokenStore.
 func (t *UserStore) InsertToken(ctx context.Context, token *models.UserToken) error {
-	query := `INSERT INTO user_tokens(token_hash, user_id, scope, expires_at)
-	VALUES($1, $2, $3, $4);`
+	query := `INSERT INTO user_tokens(token_hash, user_id, scope, expires_at, session_id, created_at, user_agent, ip_address)
+	VALUES($1, $2, $3, $4, NULLIF($5, '00000000-0000-0000-0000-000000000000'::uuid), $6, NULLIF($7, ''), NULLIF($8, ''));`
 
-	_, err := t.conn.Exec(ctx, query, token.Hash, token.UserId, token.Scope, token.ExpiresAt)
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now().UTC()
+	}
+
+	_, err := db(ctx, t.conn).Exec(ctx, query, token.Hash, token.UserId, token.Scope, token.ExpiresAt, token.SessionId, token.CreatedAt, token.UserAgent, token.IPAddress)
 	if err != nil {
 		slog.Error("failed to insert token", "error", err)
 		return err
@@ -166,6 +179,7 @@ func (t *UserStore) GetUserForToken(ctx context.Context, tokenHash string, scope
 	users.email,
 	users.password_hash,
 	users.profile_photo,
+	users.photo_key,
 	users.verified,
 	users.created_at,
 	users.last_modified
@@ -179,8 +193,8 @@ func (t *UserStore) GetUserForToken(ctx context.Context, tokenHash string, scope
 	`
 
 	var user models.User
-	row := t.conn.QueryRow(ctx, query, tokenHash, scope, email)
-	err := row.Scan(&user.Id, &user.Name, &user.Email, &user.PasswordHash, &user.ProfilePhoto, &user.Verified, &user.CreatedAt, &user.LastModifed)
+	row := db(ctx, t.conn).QueryRow(ctx, query, tokenHash, scope, email)
+	err := row.Scan(&user.Id, &user.Name, &user.Email, &user.PasswordHash, &user.ProfilePhoto, &user.PhotoKey, &user.Verified, &user.CreatedAt, &user.LastModifed)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return models.User{}, models.ErrNotFound
@@ -196,7 +210,7 @@ func (t *UserStore) GetUserForToken(ctx context.Context, tokenHash string, scope
 func (t *UserStore) DeleteToken(ctx context.Context, tokenHash, scope string) error {
 	query := `DELETE FROM user_tokens WHERE token_hash = $1 AND scope = $2;`
 
-	_, err := t.conn.Exec(ctx, query, tokenHash, scope)
+	_, err := db(ctx, t.conn).Exec(ctx, query, tokenHash, scope)
 	if err != nil {
 		slog.Error("failed to delete user token", "error", err)
 		return err
@@ -204,3 +218,162 @@ func (t *UserStore) DeleteToken(ctx context.Context, tokenHash, scope string) er
 
 	return nil
 }
+
+// DeleteTokensForUser implements models.UserStore.
+func (t *UserStore) DeleteTokensForUser(ctx context.Context, userId uuid.UUID, scope string) error {
+	query := `DELETE FROM user_tokens WHERE user_id = $1 AND scope = $2;`
+
+	_, err := db(ctx, t.conn).Exec(ctx, query, userId, scope)
+	if err != nil {
+		slog.Error("failed to delete user tokens", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetToken implements models.UserStore.
+func (t *UserStore) GetToken(ctx context.Context, tokenHash, scope string) (models.UserToken, error) {
+	query := `SELECT
+	token_hash,
+	user_id,
+	expires_at,
+	scope,
+	COALESCE(session_id, '00000000-0000-0000-0000-000000000000'),
+	created_at,
+	COALESCE(user_agent, ''),
+	COALESCE(ip_address, ''),
+	COALESCE(rotated_at, '0001-01-01 00:00:00')
+	FROM user_tokens
+	WHERE token_hash = $1 AND scope = $2 AND expires_at > now();`
+
+	var token models.UserToken
+	err := db(ctx, t.conn).QueryRow(ctx, query, tokenHash, scope).Scan(
+		&token.Hash,
+		&token.UserId,
+		&token.ExpiresAt,
+		&token.Scope,
+		&token.SessionId,
+		&token.CreatedAt,
+		&token.UserAgent,
+		&token.IPAddress,
+		&token.RotatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.UserToken{}, models.ErrNotFound
+		}
+		slog.Error("failed to fetch token", "error", err)
+		return models.UserToken{}, err
+	}
+
+	return token, nil
+}
+
+// GetSessions implements models.UserStore.
+func (t *UserStore) GetSessions(ctx context.Context, userId uuid.UUID) ([]models.Session, error) {
+	query := `SELECT
+	session_id,
+	COALESCE(user_agent, ''),
+	COALESCE(ip_address, ''),
+	created_at,
+	expires_at
+	FROM user_tokens
+	WHERE user_id = $1
+	AND scope = 'authentication'
+	AND session_id IS NOT NULL
+	AND rotated_at IS NULL
+	AND expires_at > now()
+	ORDER BY created_at DESC;`
+
+	rows, err := db(ctx, t.conn).Query(ctx, query, userId)
+	if err != nil {
+		slog.Error("failed to query sessions", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []models.Session{}
+	for rows.Next() {
+		var session models.Session
+		err := rows.Scan(&session.Id, &session.UserAgent, &session.IPAddress, &session.CreatedAt, &session.ExpiresAt)
+		if err != nil {
+			slog.Error("failed to scan session", "error", err)
+			return nil, err
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+// DeleteSession implements models.UserStore.
+func (t *UserStore) DeleteSession(ctx context.Context, userId, sessionId uuid.UUID) error {
+	query := `DELETE FROM user_tokens
+	WHERE user_id = $1 AND session_id = $2 AND scope = 'authentication';`
+
+	result, err := db(ctx, t.conn).Exec(ctx, query, userId, sessionId)
+	if err != nil {
+		slog.Error("failed to delete session", "error", err)
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+// RotateToken implements models.UserStore. The current token is marked as
+// rotated rather than deleted so that a later reuse of it can be detected.
+// Rotated tokens of the user that have expired can no longer be presented and
+// are purged. It returns models.ErrNotFound if the token has already been
+// rotated.
+func (t *UserStore) RotateToken(ctx context.Context, tokenHash string, next *models.UserToken) error {
+	rotateQuery := `UPDATE user_tokens SET rotated_at = now()
+	WHERE token_hash = $1 AND scope = 'authentication' AND rotated_at IS NULL;`
+
+	purgeQuery := `DELETE FROM user_tokens
+	WHERE user_id = $1 AND scope = 'authentication' AND rotated_at IS NOT NULL AND expires_at <= now();`
+
+	insertQuery := `INSERT INTO user_tokens(token_hash, user_id, scope, expires_at, session_id, created_at, user_agent, ip_address)
+	VALUES($1, $2, $3, $4, NULLIF($5, '00000000-0000-0000-0000-000000000000'::uuid), $6, NULLIF($7, ''), NULLIF($8, ''));`
+
+	tx, err := db(ctx, t.conn).Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, rotateQuery, tokenHash)
+	if err != nil {
+		slog.Error("failed to rotate token", "error", err)
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	_, err = tx.Exec(ctx, insertQuery, next.Hash, next.UserId, next.Scope, next.ExpiresAt, next.SessionId, next.CreatedAt, next.UserAgent, next.IPAddress)
+	if err != nil {
+		slog.Error("failed to insert rotated token", "error", err)
+		return err
+	}
+
+	_, err = tx.Exec(ctx, purgeQuery, next.UserId)
+	if err != nil {
+		slog.Error("failed to purge expired rotated tokens", "error", err)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("failed to complete transactions", "error", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateView implements models.WorkspaceStore.
+func (w *WorkspaceStore) CreateView(ctx context.Context, view *models.View) error {
+	query := `INSERT INTO views(id, workspace_id, project_id, owner_id, name, shared, filter, group_by, created_at, last_modified)
+	VALUES($1, $2, NULLIF($3, '00000000-0000-0000-0000-000000000000'::uuid), $4, $5, $6, $7, $8, $9, $10);`
+
+	_, err := db(ctx, w.conn).Exec(ctx, query,
+		view.Id,
+		view.WorkspaceId,
+		view.ProjectId,
+		view.OwnerId,
+		view.Name,
+		view.Shared,
+		view.Filter,
+		view.GroupBy,
+		view.CreatedAt,
+		view.LastModified,
+	)
+	if err != nil {
+		slog.Error("failed to insert view", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+const viewColumns = `id,
+	workspace_id,
+	COALESCE(project_id, '00000000-0000-0000-0000-000000000000'),
+	owner_id,
+	name,
+	shared,
+	filter,
+	group_by,
+	created_at,
+	last_modified`
+
+func scanView(row pgx.Row) (models.View, error) {
+	var view models.View
+	err := row.Scan(&view.Id, &view.WorkspaceId, &view.ProjectId, &view.OwnerId, &view.Name, &view.Shared, &view.Filter, &view.GroupBy, &view.CreatedAt, &view.LastModified)
+	return view, err
+}
+
+// GetView implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetView(ctx context.Context, id uuid.UUID) (*models.View, error) {
+	query := `SELECT ` + viewColumns + ` FROM views WHERE id = $1;`
+
+	view, err := scanView(db(ctx, w.conn).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		slog.Error("failed to read view", "error", err.Error())
+		return nil, err
+	}
+
+	return &view, nil
+}
+
+// GetWorkspaceViews implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetWorkspaceViews(ctx context.Context, workspaceId, userId uuid.UUID) ([]models.View, error) {
+	query := `SELECT ` + viewColumns + ` FROM views
+	WHERE workspace_id = $1 AND (owner_id = $2 OR shared)
+	ORDER BY lower(name), id;`
+
+	rows, err := db(ctx, w.conn).Query(ctx, query, workspaceId, userId)
+	if err != nil {
+		slog.Error("failed to query views", "error", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	views := []models.View{}
+	for rows.Next() {
+		view, err := scanView(rows)
+		if err != nil {
+			slog.Error("failed to scan view", "error", err.Error())
+			return nil, err
+		}
+		views = append(views, view)
+	}
+
+	return views, rows.Err()
+}
+
+// UpdateView implements models.WorkspaceStore.
+func (w *WorkspaceStore) UpdateView(ctx context.Context, view *models.View) error {
+	query := `UPDATE views
+	SET project_id = NULLIF($1, '00000000-0000-0000-0000-000000000000'::uuid), name = $2, shared = $3, filter = $4, group_by = $5, last_modified = $6
+	WHERE id = $7;`
+
+	result, err := db(ctx, w.conn).Exec(ctx, query, view.ProjectId, view.Name, view.Shared, view.Filter, view.GroupBy, view.LastModified, view.Id)
+	if err != nil {
+		slog.Error("failed to update view", "error", err.Error())
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteView implements models.WorkspaceStore.
+func (w *WorkspaceStore) DeleteView(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM views WHERE id = $1;`
+
+	result, err := db(ctx, w.conn).Exec(ctx, query, id)
+	if err != nil {
+		slog.Error("failed to delete view", "error", err.Error())
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 
 	"github.com/primekobie/hazel/models"
@@ -19,6 +20,11 @@ func NewWorkspaceStore(conn *pgxpool.Pool) models.WorkspaceStore {
 	return &WorkspaceStore{conn: conn}
 }
 
+// WithinTx implements models.WorkspaceStore.
+func (w *WorkspaceStore) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTx(ctx, w.conn, fn)
+}
+
 // Create implements models.WorkspaceStore.
 func (w *WorkspaceStore) Create(ctx context.Context, ws *models.Workspace) error {
 	wsquery := `INSERT INTO workspaces(id, name, description, user_id, created_at, last_modified)
@@ -27,7 +33,7 @@ func (w *WorkspaceStore) Create(ctx context.Context, ws *models.Workspace) error
 	memberquery := `INSERT INTO workspace_memberships(workspace_id, user_id, role)
 	VALUES($1, $2, $3);`
 
-	tx, err := w.conn.Begin(ctx)
+	tx, err := db(ctx, w.conn).Begin(ctx)
 	if err != nil {
 		slog.Error("failed to start transaction", "error", err)
 		return err
@@ -74,7 +80,7 @@ func (w *WorkspaceStore) Get(ctx context.Context, id uuid.UUID) (*models.Workspa
 	ON w.user_id = u.id
 	WHERE w.id = $1;`
 
-	row := w.conn.QueryRow(ctx, query, id)
+	row := db(ctx, w.conn).QueryRow(ctx, query, id)
 
 	ws := models.Workspace{
 		User: &models.User{},
@@ -112,7 +118,7 @@ func (w *WorkspaceStore) GetAllForUser(ctx context.Context, userId uuid.UUID) ([
 	INNER JOIN users AS u	ON w.user_id = u.id
 	WHERE wm.user_id = $1;`
 
-	rows, err := w.conn.Query(ctx, query, userId)
+	rows, err := db(ctx, w.conn).Query(ctx, query, userId)
 	if err != nil {
 		slog.Error("failed to query rows", "error", err.Error())
 		return nil, err
@@ -141,7 +147,7 @@ func (w *WorkspaceStore) Update(ctx context.Context, workspace *models.Workspace
 	query := `UPDATE workspaces SET name = $1, description = $2, last_modified = now()
 	WHERE id = $3;`
 
-	_, err := w.conn.Exec(ctx, query, workspace.Name, workspace.Description, workspace.Id)
+	_, err := db(ctx, w.conn).Exec(ctx, query, workspace.Name, workspace.Description, workspace.Id)
 	if err != nil {
 		slog.Error("failed to update workspace", "error", err.Error())
 		return err
@@ -154,7 +160,7 @@ func (w *WorkspaceStore) Update(ctx context.Context, workspace *models.Workspace
 func (w *WorkspaceStore) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM workspaces WHERE id = $1;`
 
-	_, err := w.conn.Exec(ctx, query, id)
+	_, err := db(ctx, w.conn).Exec(ctx, query, id)
 
 	if err != nil {
 		slog.Error("failed to create workspace", "error", err)
@@ -164,63 +170,54 @@ func (w *WorkspaceStore) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (w *WorkspaceStore) AddMembership(ctx context.Context, workspaceId, userId uuid.UUID, role string) error {
-	query := `INSERT INTO workspace_memberships(workspace_id, user_id, role, created_at)
-	VALUES($1, $2, $3, now());`
-
-	_, err := w.conn.Exec(ctx, query, workspaceId, userId, role)
-	if err != nil {
-		slog.Error("failed to insert membership", "error", err.Error())
-		return err
-	}
-
-	return nil
+// memberSorts lists the orderings available to member listings.
+var memberSorts = map[string]sortKey{
+	"joined_at": {expr: "wm.created_at", cast: "timestamp"},
+	"name":      {expr: "u.name", cast: "text"},
 }
 
 // GetWorkspaceMembers implements models.WorkspaceStore.
-func (w *WorkspaceStore) GetWorkspaceMembers(ctx context.Context, workspaceId uuid.UUID) ([]models.User, error) {
-	query := `SELECT
-	u.id,
+func (w *WorkspaceStore) GetWorkspaceMembers(ctx context.Context, workspaceId uuid.UUID, filter models.MemberFilter) (*models.Page[models.User], error) {
+	q := &listQuery{
+		columns: `u.id,
 	u.name,
 	u.email,
 	u.profile_photo,
+	wm.role,
 	u.created_at,
-	u.last_modified
-	FROM workspace_memberships AS wm
-	INNER JOIN users AS u ON wm.user_id = u.id
-	WHERE wm.workspace_id = $1;`
+	u.last_modified`,
+		from:        "workspace_memberships AS wm INNER JOIN users AS u ON wm.user_id = u.id",
+		id:          "u.id",
+		sorts:       memberSorts,
+		defaultSort: "joined_at",
+	}
 
-	users := []models.User{}
+	q.where("wm.workspace_id = %s", workspaceId)
 
-	rows, err := w.conn.Query(ctx, query, workspaceId)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, models.ErrNotFound
+	if len(filter.Role) > 0 {
+		roles := make([]string, len(filter.Role))
+		for i, role := range filter.Role {
+			roles[i] = string(role)
 		}
-
-		slog.Error("failed to fetch users", "error", err.Error())
-		return nil, err
+		q.where("wm.role = ANY(%s)", roles)
 	}
 
-	for rows.Next() {
-		user := models.User{}
-		err := rows.Scan(&user.Id, &user.Name, &user.Email, &user.ProfilePhoto, &user.CreatedAt, &user.LastModifed)
-		if err != nil {
-			slog.Error("failed to scan users", "error", err.Error())
-			return nil, err
-		}
-
-		users = append(users, user)
+	if filter.Search != "" {
+		q.where("(u.name ILIKE %[1]s OR u.email ILIKE %[1]s)", likePattern(filter.Search))
 	}
 
-	return users, nil
+	return fetchPage(ctx, db(ctx, w.conn), q, filter.ListOptions, func(rows pgx.Rows, sortValue *string, id *uuid.UUID) (models.User, error) {
+		user := models.User{}
+		err := rows.Scan(&user.Id, &user.Name, &user.Email, &user.ProfilePhoto, &user.Role, &user.CreatedAt, &user.LastModifed, sortValue, id)
+		return user, err
+	})
 }
 
 func (w *WorkspaceStore) DeleteMembership(ctx context.Context, workspaceId, userId uuid.UUID) error {
 	delQuery := `DELETE FROM workspace_memberships
 	WHERE workspace_id = $1 AND user_id = $2 AND NOT role = 'owner';`
 
-	_, err := w.conn.Exec(ctx, delQuery, workspaceId, userId)
+	_, err := db(ctx, w.conn).Exec(ctx, delQuery, workspaceId, userId)
 	if err != nil {
 		slog.Error("failed to delete membership", "error", err.Error())
 		return err
@@ -228,3 +225,61 @@ func (w *WorkspaceStore) DeleteMembership(ctx context.Context, workspaceId, user
 
 	return nil
 }
+
+// GetMemberRole implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetMemberRole(ctx context.Context, workspaceId, userId uuid.UUID) (models.Role, error) {
+	query := `SELECT role FROM workspace_memberships
+	WHERE workspace_id = $1 AND user_id = $2;`
+
+	var role models.Role
+	err := db(ctx, w.conn).QueryRow(ctx, query, workspaceId, userId).Scan(&role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", models.ErrNotFound
+		}
+		slog.Error("failed to fetch member role", "error", err.Error())
+		return "", err
+	}
+
+	return role, nil
+}
+
+// GetResourceWorkspace implements models.WorkspaceStore.
+func (w *WorkspaceStore) GetResourceWorkspace(ctx context.Context, resource models.Resource, id uuid.UUID) (uuid.UUID, error) {
+	var query string
+	switch resource {
+	case models.ResourceWorkspace:
+		query = `SELECT id FROM workspaces WHERE id = $1;`
+	case models.ResourceProject:
+		query = `SELECT workspace_id FROM projects WHERE id = $1;`
+	case models.ResourceTask:
+		query = `SELECT p.workspace_id
+		FROM tasks AS t
+		INNER JOIN projects AS p ON t.project_id = p.id
+		WHERE t.id = $1;`
+	case models.ResourceComment:
+		query = `SELECT p.workspace_id
+		FROM task_comments AS c
+		INNER JOIN tasks AS t ON c.task_id = t.id
+		INNER JOIN projects AS p ON t.project_id = p.id
+		WHERE c.id = $1;`
+	case models.ResourceInvitation:
+		query = `SELECT workspace_id FROM workspace_invitations WHERE id = $1;`
+	case models.ResourceView:
+		query = `SELECT workspace_id FROM views WHERE id = $1;`
+	default:
+		return uuid.Nil, fmt.Errorf("unknown resource type: %s", resource)
+	}
+
+	var workspaceId uuid.UUID
+	err := db(ctx, w.conn).QueryRow(ctx, query, id).Scan(&workspaceId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, models.ErrNotFound
+		}
+		slog.Error("failed to resolve resource workspace", "resource", resource, "error", err.Error())
+		return uuid.Nil, err
+	}
+
+	return workspaceId, nil
+}
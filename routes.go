@@ -3,6 +3,7 @@ package main
 import (
 	"github.com/primekobie/hazel/docs"
 	"github.com/primekobie/hazel/middlewares"
+	"github.com/primekobie/hazel/models"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -28,43 +29,162 @@ func (app *application) routes() *gin.Engine {
 	open.POST("/auth/register", app.handler.CreateUser)
 	open.POST("/auth/login", app.handler.LoginUser)
 	open.POST("/auth/access", app.handler.GetUserAccessToken)
+	open.POST("/auth/logout", app.handler.LogoutUser)
 	open.POST("/auth/verify", app.handler.VerifyUser)
 	open.POST("/auth/verify/request", app.handler.RequestVerification)
+	open.POST("/auth/password/forgot", app.handler.ForgotPassword)
+	open.POST("/auth/password/reset", app.handler.ResetPassword)
 
 	protected := open.Group("/")
 	protected.Use(middlewares.Authentication())
+
+	authz := app.authorizer
+	workspace := func(role models.Role) gin.HandlerFunc {
+		return authz.Require(role, models.ResourceWorkspace, middlewares.Param("id"))
+	}
+	project := func(role models.Role) gin.HandlerFunc {
+		return authz.Require(role, models.ResourceProject, middlewares.Param("id"))
+	}
+	task := func(role models.Role) gin.HandlerFunc {
+		return authz.Require(role, models.ResourceTask, middlewares.Param("id"))
+	}
+	view := func(role models.Role) gin.HandlerFunc {
+		return authz.Require(role, models.ResourceView, middlewares.Param("id"))
+	}
 	{
 		//users
 		protected.GET("/users/:id", app.handler.GetUser)
 		protected.PATCH("/users/profile", app.handler.UpdateUserData)
-		protected.DELETE("/users/:id", app.handler.DeleteUser)
+		protected.PUT("/users/profile/photo", app.handler.SetProfilePhoto)
+		protected.DELETE("/users/profile/photo", app.handler.DeleteProfilePhoto)
+		protected.GET("/users/:id/photo", app.handler.GetProfilePhoto)
+		protected.GET("/users/me/sessions", app.handler.GetUserSessions)
+		protected.DELETE("/users/me/sessions", app.handler.DeleteAllUserSessions)
+		protected.DELETE("/users/me/sessions/:id", app.handler.DeleteUserSession)
+		protected.GET("/users/me/invitations", app.handler.GetUserInvitations)
+		protected.GET("/users/me/timer", app.handler.GetRunningTimer)
+		protected.GET("/users/me/notifications", app.handler.GetNotifications)
+		protected.POST("/users/me/notifications/read", app.handler.MarkAllNotificationsRead)
+		protected.POST("/users/me/notifications/:id/read", app.handler.MarkNotificationRead)
+		protected.GET("/users/me/notifications/settings", app.handler.GetNotificationSettings)
+		protected.PUT("/users/me/notifications/settings", app.handler.UpdateNotificationSettings)
+		protected.DELETE("/users/:id", middlewares.Self("id"), app.handler.DeleteUser)
 
 		// workspaces
 		protected.POST("/workspaces", app.handler.CreateWorkspace)
-		protected.GET("/workspaces/:id", app.handler.GetWorkspace)
+		protected.GET("/workspaces/:id", workspace(models.RoleViewer), app.handler.GetWorkspace)
 		protected.GET("/workspaces/me", app.handler.GetUserWorkspaces)
-		protected.PATCH("/workspaces/:id", app.handler.UpdateWorkspace)
-		protected.DELETE("/workspaces/:id", app.handler.DeleteWorkspace)
-		protected.POST("/workspaces/:id/members", app.handler.AddWorkspaceMember)
-		protected.GET("/workspaces/:id/members", app.handler.GetWorkspaceMembers)
-		protected.DELETE("/workspaces/:id/members/:user_id", app.handler.DeleteWorkspaceMember)
-		protected.GET("/workspaces/:id/projects", app.handler.GetProjectsInWorkspace)
+		protected.PATCH("/workspaces/:id", workspace(models.RoleAdmin), app.handler.UpdateWorkspace)
+		protected.DELETE("/workspaces/:id", workspace(models.RoleOwner), app.handler.DeleteWorkspace)
+		protected.GET("/workspaces/:id/members", workspace(models.RoleViewer), app.handler.GetWorkspaceMembers)
+		protected.DELETE("/workspaces/:id/members/:user_id", workspace(models.RoleViewer), app.handler.DeleteWorkspaceMember)
+		protected.GET("/workspaces/:id/projects", workspace(models.RoleViewer), app.handler.GetProjectsInWorkspace)
+		protected.GET("/workspaces/:id/activity", workspace(models.RoleViewer), app.handler.GetWorkspaceActivity)
+		protected.GET("/workspaces/:id/events", workspace(models.RoleViewer), app.handler.StreamWorkspaceEvents)
+		protected.GET("/workspaces/:id/time", workspace(models.RoleViewer), app.handler.GetWorkspaceTimeReport)
+		protected.GET("/workspaces/:id/search", workspace(models.RoleViewer), app.handler.SearchWorkspace)
+
+		// invitations
+		protected.POST("/workspaces/:id/invitations", workspace(models.RoleAdmin), app.handler.CreateInvitation)
+		protected.GET("/workspaces/:id/invitations", workspace(models.RoleAdmin), app.handler.GetWorkspaceInvitations)
+		protected.DELETE("/workspaces/:id/invitations/:invitation_id", workspace(models.RoleAdmin), app.handler.DeleteInvitation)
+		protected.POST("/invitations/:id/accept", app.handler.AcceptInvitation)
+		protected.POST("/invitations/:id/decline", app.handler.DeclineInvitation)
+		protected.POST("/workspaces/:id/labels", workspace(models.RoleMember), app.handler.CreateLabel)
+		protected.GET("/workspaces/:id/labels", workspace(models.RoleViewer), app.handler.GetWorkspaceLabels)
+		protected.PATCH("/workspaces/:id/labels/:label_id", workspace(models.RoleMember), app.handler.UpdateLabel)
+		protected.DELETE("/workspaces/:id/labels/:label_id", workspace(models.RoleAdmin), app.handler.DeleteLabel)
+
+		// views
+		protected.POST("/workspaces/:id/views", workspace(models.RoleViewer), app.handler.CreateView)
+		protected.GET("/workspaces/:id/views", workspace(models.RoleViewer), app.handler.GetWorkspaceViews)
+		protected.GET("/views/:id", view(models.RoleViewer), app.handler.GetView)
+		protected.PATCH("/views/:id", view(models.RoleViewer), app.handler.UpdateView)
+		protected.DELETE("/views/:id", view(models.RoleViewer), app.handler.DeleteView)
+		protected.GET("/views/:id/tasks", view(models.RoleViewer), app.handler.GetViewTasks)
 
 		// projects
-		protected.POST("/projects", app.handler.CreateProject)
-		protected.GET("/projects/:id", app.handler.GetProject)
-		protected.PATCH("/projects/:id", app.handler.UpdateProject)
-		protected.DELETE("/projects/:id", app.handler.DeleteProject)
-		protected.GET("/projects/:id/tasks", app.handler.GetProjectTasks)
+		protected.POST("/projects", authz.Require(models.RoleMember, models.ResourceWorkspace, middlewares.Body("workspaceId")), app.handler.CreateProject)
+		protected.GET("/projects/:id", project(models.RoleViewer), app.handler.GetProject)
+		protected.PATCH("/projects/:id", project(models.RoleMember), app.handler.UpdateProject)
+		protected.DELETE("/projects/:id", project(models.RoleAdmin), app.handler.DeleteProject)
+		protected.GET("/projects/:id/tasks", project(models.RoleViewer), app.handler.GetProjectTasks)
+		protected.GET("/projects/:id/time", project(models.RoleViewer), app.handler.GetProjectTimeReport)
+
+		// workflows
+		protected.GET("/projects/:id/statuses", project(models.RoleViewer), app.handler.GetProjectStatuses)
+		protected.POST("/projects/:id/statuses", project(models.RoleAdmin), app.handler.CreateProjectStatus)
+		protected.PATCH("/projects/:id/statuses/:status_id", project(models.RoleAdmin), app.handler.UpdateProjectStatus)
+		protected.DELETE("/projects/:id/statuses/:status_id", project(models.RoleAdmin), app.handler.DeleteProjectStatus)
+		protected.GET("/projects/:id/transitions", project(models.RoleViewer), app.handler.GetStatusTransitions)
+		protected.PUT("/projects/:id/transitions", project(models.RoleAdmin), app.handler.SetStatusTransitions)
+
+		// sprints
+		protected.POST("/projects/:id/sprints", project(models.RoleMember), app.handler.CreateSprint)
+		protected.GET("/projects/:id/sprints", project(models.RoleViewer), app.handler.GetProjectSprints)
+		protected.GET("/projects/:id/sprints/:sprint_id", project(models.RoleViewer), app.handler.GetSprint)
+		protected.PATCH("/projects/:id/sprints/:sprint_id", project(models.RoleMember), app.handler.UpdateSprint)
+		protected.DELETE("/projects/:id/sprints/:sprint_id", project(models.RoleAdmin), app.handler.DeleteSprint)
+		protected.POST("/projects/:id/sprints/:sprint_id/start", project(models.RoleMember), app.handler.StartSprint)
+		protected.POST("/projects/:id/sprints/:sprint_id/close", project(models.RoleMember), app.handler.CloseSprint)
+		protected.GET("/projects/:id/sprints/:sprint_id/summary", project(models.RoleViewer), app.handler.GetSprintSummary)
+
+		// milestones
+		protected.POST("/projects/:id/milestones", project(models.RoleMember), app.handler.CreateMilestone)
+		protected.GET("/projects/:id/milestones", project(models.RoleViewer), app.handler.GetProjectMilestones)
+		protected.GET("/projects/:id/milestones/:milestone_id", project(models.RoleViewer), app.handler.GetMilestone)
+		protected.PATCH("/projects/:id/milestones/:milestone_id", project(models.RoleMember), app.handler.UpdateMilestone)
+		protected.DELETE("/projects/:id/milestones/:milestone_id", project(models.RoleAdmin), app.handler.DeleteMilestone)
 
 		// Tasks
-		protected.POST("/tasks", app.handler.CreateTask)
-		protected.GET("/tasks/:id", app.handler.GetTask)
-		protected.PATCH("/tasks/:id", app.handler.UpdateTask)
-		protected.DELETE("/tasks/:id", app.handler.DeleteTask)
-		protected.POST("/tasks/:id/assignments", app.handler.AssignTaskToUser)
-		protected.GET("/tasks/:id/assignments", app.handler.GetAssignedUsers)
-		protected.DELETE("/tasks/:id/assignments/:user_id", app.handler.RemoveAssignment)
+		protected.POST("/tasks", authz.Require(models.RoleMember, models.ResourceProject, middlewares.Body("projectId")), app.handler.CreateTask)
+		protected.GET("/tasks/:id", task(models.RoleViewer), app.handler.GetTask)
+		protected.PATCH("/tasks/:id", task(models.RoleMember), app.handler.UpdateTask)
+		protected.DELETE("/tasks/:id", task(models.RoleMember), app.handler.DeleteTask)
+		protected.POST("/tasks/:id/move", task(models.RoleMember), app.handler.MoveTask)
+		protected.POST("/tasks/:id/assignments", task(models.RoleMember), app.handler.AssignTaskToUser)
+		protected.GET("/tasks/:id/assignments", task(models.RoleViewer), app.handler.GetAssignedUsers)
+		protected.DELETE("/tasks/:id/assignments/:user_id", task(models.RoleMember), app.handler.RemoveAssignment)
+		protected.GET("/tasks/:id/subtasks", task(models.RoleViewer), app.handler.GetSubtasks)
+		protected.GET("/tasks/:id/dependencies", task(models.RoleViewer), app.handler.GetTaskDependencies)
+		protected.POST("/tasks/:id/dependencies", task(models.RoleMember), app.handler.AddTaskDependency)
+		protected.DELETE("/tasks/:id/dependencies/:blocker_id", task(models.RoleMember), app.handler.RemoveTaskDependency)
+		protected.POST("/tasks/:id/labels", task(models.RoleMember), app.handler.AttachTaskLabel)
+		protected.DELETE("/tasks/:id/labels/:label_id", task(models.RoleMember), app.handler.DetachTaskLabel)
+
+		// attachments
+		protected.POST("/tasks/:id/attachments", task(models.RoleMember), app.handler.AddTaskAttachment)
+		protected.GET("/tasks/:id/attachments", task(models.RoleViewer), app.handler.GetTaskAttachments)
+		protected.GET("/tasks/:id/attachments/:attachment_id", task(models.RoleViewer), app.handler.DownloadTaskAttachment)
+		protected.DELETE("/tasks/:id/attachments/:attachment_id", task(models.RoleMember), app.handler.DeleteTaskAttachment)
+
+		// time tracking
+		protected.POST("/tasks/:id/time/start", task(models.RoleMember), app.handler.StartTimer)
+		protected.POST("/tasks/:id/time/stop", task(models.RoleMember), app.handler.StopTimer)
+		protected.POST("/tasks/:id/time", task(models.RoleMember), app.handler.LogTime)
+		protected.GET("/tasks/:id/time", task(models.RoleViewer), app.handler.GetTaskTimeEntries)
+		protected.PATCH("/tasks/:id/time/:entry_id", task(models.RoleMember), app.handler.UpdateTimeEntry)
+		protected.DELETE("/tasks/:id/time/:entry_id", task(models.RoleMember), app.handler.DeleteTimeEntry)
+
+		// checklists
+		protected.POST("/tasks/:id/checklist", task(models.RoleMember), app.handler.CreateChecklistItem)
+		protected.GET("/tasks/:id/checklist", task(models.RoleViewer), app.handler.GetChecklist)
+		protected.PATCH("/tasks/:id/checklist/:item_id", task(models.RoleMember), app.handler.UpdateChecklistItem)
+		protected.DELETE("/tasks/:id/checklist/:item_id", task(models.RoleMember), app.handler.DeleteChecklistItem)
+
+		// comments
+		protected.POST("/tasks/:id/comments", task(models.RoleMember), app.handler.CreateComment)
+		protected.GET("/tasks/:id/comments", task(models.RoleViewer), app.handler.GetTaskComments)
+		protected.PATCH("/tasks/:id/comments/:comment_id", task(models.RoleMember), app.handler.UpdateComment)
+		protected.DELETE("/tasks/:id/comments/:comment_id", task(models.RoleMember), app.handler.DeleteComment)
+	}
+
+	// development mail sink
+	if app.devMail != nil {
+		dev := router.Group("/dev/mail")
+		dev.GET("", app.devMail.GetMessages)
+		dev.GET("/:id", app.devMail.GetMessage)
+		dev.GET("/:id/html", app.devMail.PreviewMessage)
 	}
 
 	// swagger
@@ -6,21 +6,26 @@ import (
 	"net/http"
 
 	"github.com/primekobie/hazel/handlers"
+	"github.com/primekobie/hazel/middlewares"
 )
 
 type application struct {
-	handler *handlers.Handler
-	server  *http.Server
+	handler    *handlers.Handler
+	authorizer *middlewares.Authorizer
+	server     *http.Server
+	// devMail serves the development mail sink, and is nil unless it is in use
+	devMail *handlers.DevMail
 }
 
-func newApplication(handler *handlers.Handler, address string) *application {
+func newApplication(handler *handlers.Handler, authorizer *middlewares.Authorizer, address string) *application {
 	server := http.Server{
 		Addr: fmt.Sprintf(":%s", address),
 	}
 
 	return &application{
-		handler: handler,
-		server:  &server,
+		handler:    handler,
+		authorizer: authorizer,
+		server:     &server,
 	}
 }
 
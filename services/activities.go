@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"time"
+
+	"github.com/primekobie/hazel/auth"
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+)
+
+// fields is a snapshot of the audited fields of an entity.
+type fields map[string]any
+
+// diff returns the fields whose values differ between before and after.
+// Either snapshot may be nil for entities that were created or deleted.
+func diff(before, after fields) map[string]models.Change {
+	changes := map[string]models.Change{}
+	for key, old := range before {
+		if value, ok := after[key]; !ok || !reflect.DeepEqual(old, value) {
+			changes[key] = models.Change{Before: old, After: after[key]}
+		}
+	}
+	for key, value := range after {
+		if _, ok := before[key]; !ok {
+			changes[key] = models.Change{Before: nil, After: value}
+		}
+	}
+	return changes
+}
+
+// timeField formats t for a snapshot, leaving zero times empty.
+func timeField(t time.Time, layout string) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.Format(layout)
+}
+
+// idField leaves unset ids empty in a snapshot.
+func idField(id uuid.UUID) any {
+	if id == uuid.Nil {
+		return nil
+	}
+	return id.String()
+}
+
+func workspaceFields(ws *models.Workspace) fields {
+	return fields{
+		"name":        ws.Name,
+		"description": ws.Description,
+	}
+}
+
+func projectFields(project *models.Project) fields {
+	return fields{
+		"name":        project.Name,
+		"description": project.Description,
+		"startDate":   timeField(project.StartDate.Time, models.DateLayout),
+		"endDate":     timeField(project.EndDate.Time, models.DateLayout),
+	}
+}
+
+func taskFields(task *models.Task) fields {
+	return fields{
+		"title":       task.Title,
+		"description": task.Description,
+		"parentId":    idField(task.ParentId),
+		"sprintId":    idField(task.SprintId),
+		"milestoneId": idField(task.MilestoneId),
+		"status":      string(task.Status),
+		"priority":    string(task.Priority),
+		"due":         timeField(task.Due, time.RFC3339),
+		"estimate":    task.Estimate,
+	}
+}
+
+// record appends an entry to the workspace's activity log on behalf of the
+// authenticated user and publishes it to the workspace's event stream.
+// Failures are logged rather than returned so that a completed mutation is
+// never reported as failed.
+func (s *WorkspaceService) record(ctx context.Context, workspaceId uuid.UUID, entityType models.Resource, entityId uuid.UUID, action string, changes map[string]models.Change) {
+	activity := newActivity(ctx, workspaceId, entityType, entityId, action, changes)
+
+	err := s.store.InsertActivity(ctx, activity)
+	if err != nil {
+		slog.Error("failed to record activity", "error", err, "entity_type", entityType, "entity_id", entityId, "action", action)
+	}
+
+	s.publish(activity)
+}
+
+func newActivity(ctx context.Context, workspaceId uuid.UUID, entityType models.Resource, entityId uuid.UUID, action string, changes map[string]models.Change) *models.Activity {
+	activity := &models.Activity{
+		Id:          uuid.New(),
+		WorkspaceId: workspaceId,
+		EntityType:  entityType,
+		EntityId:    entityId,
+		Action:      action,
+		Changes:     changes,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	if actorId, ok := auth.UserIDFromContext(ctx); ok {
+		activity.Actor = &models.User{Id: actorId}
+	}
+
+	return activity
+}
+
+// recordFor is like record but first resolves the workspace owning the
+// resource identified by resourceId.
+func (s *WorkspaceService) recordFor(ctx context.Context, resource models.Resource, resourceId uuid.UUID, entityType models.Resource, entityId uuid.UUID, action string, changes map[string]models.Change) {
+	workspaceId, err := s.store.GetResourceWorkspace(ctx, resource, resourceId)
+	if err != nil {
+		slog.Error("failed to resolve workspace for activity", "error", err, "resource", resource, "id", resourceId)
+		return
+	}
+
+	s.record(ctx, workspaceId, entityType, entityId, action, changes)
+}
+
+func (s *WorkspaceService) GetActivities(ctx context.Context, workspaceId uuid.UUID, filter models.ActivityFilter) (*models.Page[models.Activity], error) {
+	return s.store.GetActivities(ctx, workspaceId, filter)
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		before fields
+		after  fields
+		want   map[string]models.Change
+	}{
+		{name: "unchanged", before: fields{"title": "a"}, after: fields{"title": "a"}, want: map[string]models.Change{}},
+		{name: "created", before: nil, after: fields{"title": "a"}, want: map[string]models.Change{"title": {After: "a"}}},
+		{name: "deleted", before: fields{"title": "a"}, after: nil, want: map[string]models.Change{"title": {Before: "a"}}},
+		{
+			name:   "changed fields only",
+			before: fields{"title": "a", "status": "todo", "due": nil},
+			after:  fields{"title": "a", "status": "done", "due": "2025-01-02T00:00:00Z"},
+			want: map[string]models.Change{
+				"status": {Before: "todo", After: "done"},
+				"due":    {Before: nil, After: "2025-01-02T00:00:00Z"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, diff(tt.before, tt.after))
+		})
+	}
+}
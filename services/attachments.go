@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+)
+
+func attachmentFields(attachment *models.Attachment) fields {
+	return fields{
+		"name":        attachment.Name,
+		"contentType": attachment.ContentType,
+		"size":        attachment.Size,
+	}
+}
+
+// AddAttachment stores the file read from r and attaches it to the task.
+func (s *WorkspaceService) AddAttachment(ctx context.Context, taskId, userId uuid.UUID, name string, r io.Reader) (*models.Attachment, error) {
+	contentType, r, err := sniff(r)
+	if err != nil {
+		return nil, ErrFailedOperation
+	}
+	if !allowedType(contentType, attachmentTypes) {
+		return nil, ErrFileType
+	}
+
+	attachment := &models.Attachment{
+		Id:          uuid.New(),
+		TaskId:      taskId,
+		Name:        name,
+		ContentType: contentType,
+		UploadedBy:  userId,
+		CreatedAt:   time.Now().UTC(),
+	}
+	attachment.Key = fmt.Sprintf("tasks/%s/%s", taskId, attachment.Id)
+
+	attachment.Size, err = putFile(ctx, s.files, attachment.Key, r, MaxAttachmentSize)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store.CreateAttachment(ctx, attachment)
+	if err != nil {
+		deleteFile(ctx, s.files, attachment.Key)
+		return nil, err
+	}
+
+	s.recordFor(ctx, models.ResourceTask, taskId, models.ResourceAttachment, attachment.Id, models.ActionCreated, diff(nil, attachmentFields(attachment)))
+
+	return attachment, nil
+}
+
+func (s *WorkspaceService) GetTaskAttachments(ctx context.Context, taskId uuid.UUID) ([]models.Attachment, error) {
+	return s.store.GetTaskAttachments(ctx, taskId)
+}
+
+// getAttachment returns an attachment of the task, reporting attachments of
+// other tasks as not found.
+func (s *WorkspaceService) getAttachment(ctx context.Context, taskId, id uuid.UUID) (*models.Attachment, error) {
+	attachment, err := s.store.GetAttachment(ctx, id)
+	if err != nil {
+		return nil, err
+	} else if attachment.TaskId != taskId {
+		return nil, models.ErrNotFound
+	}
+
+	return attachment, nil
+}
+
+// OpenAttachment returns an attachment of the task and a reader for its
+// contents, which the caller must close.
+func (s *WorkspaceService) OpenAttachment(ctx context.Context, taskId, id uuid.UUID) (*models.Attachment, io.ReadCloser, error) {
+	attachment, err := s.getAttachment(ctx, taskId, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, err := openFile(ctx, s.files, attachment.Key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return attachment, r, nil
+}
+
+// DeleteAttachment removes an attachment of the task and its file. Files may
+// be deleted by whoever uploaded them or by a workspace admin.
+func (s *WorkspaceService) DeleteAttachment(ctx context.Context, taskId, id, userId uuid.UUID, role models.Role) error {
+	attachment, err := s.getAttachment(ctx, taskId, id)
+	if err != nil {
+		return err
+	}
+
+	if attachment.UploadedBy != userId && !role.Includes(models.RoleAdmin) {
+		return ErrPermissionDenied
+	}
+
+	err = s.store.DeleteAttachment(ctx, attachment.Id)
+	if err != nil {
+		return err
+	}
+	deleteFile(ctx, s.files, attachment.Key)
+
+	s.recordFor(ctx, models.ResourceTask, taskId, models.ResourceAttachment, attachment.Id, models.ActionDeleted, diff(attachmentFields(attachment), nil))
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/primekobie/hazel/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// attachmentStore serves a single attachment and records whether it was
+// deleted.
+type attachmentStore struct {
+	models.WorkspaceStore
+	attachment models.Attachment
+	deleted    bool
+}
+
+func (f *attachmentStore) GetAttachment(ctx context.Context, id uuid.UUID) (*models.Attachment, error) {
+	if id != f.attachment.Id {
+		return nil, models.ErrNotFound
+	}
+	attachment := f.attachment
+	return &attachment, nil
+}
+
+func (f *attachmentStore) DeleteAttachment(ctx context.Context, id uuid.UUID) error {
+	f.deleted = true
+	return nil
+}
+
+func (f *attachmentStore) GetResourceWorkspace(ctx context.Context, resource models.Resource, id uuid.UUID) (uuid.UUID, error) {
+	return uuid.Nil, models.ErrNotFound
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	taskId, uploaderId := uuid.New(), uuid.New()
+	attachment := models.Attachment{Id: uuid.New(), TaskId: taskId, Key: "attachments/report.pdf", UploadedBy: uploaderId}
+
+	tests := []struct {
+		name   string
+		userId uuid.UUID
+		role   models.Role
+		want   error
+	}{
+		{name: "uploader", userId: uploaderId, role: models.RoleMember},
+		{name: "admin", userId: uuid.New(), role: models.RoleAdmin},
+		{name: "other member", userId: uuid.New(), role: models.RoleMember, want: ErrPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := storage.NewLocal(t.TempDir())
+			require.NoError(t, err)
+
+			store := &attachmentStore{attachment: attachment}
+			s := &WorkspaceService{store: store, files: files}
+
+			err = s.DeleteAttachment(context.Background(), taskId, attachment.Id, tt.userId, tt.role)
+			assert.ErrorIs(t, err, tt.want)
+			assert.Equal(t, tt.want == nil, store.deleted)
+		})
+	}
+}
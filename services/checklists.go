@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+)
+
+func checklistFields(item *models.ChecklistItem) fields {
+	return fields{
+		"title":    item.Title,
+		"done":     item.Done,
+		"position": item.Position,
+	}
+}
+
+func (s *WorkspaceService) AddChecklistItem(ctx context.Context, taskId uuid.UUID, title string) (*models.ChecklistItem, error) {
+	now := time.Now().UTC()
+	item := &models.ChecklistItem{
+		Id:           uuid.New(),
+		TaskId:       taskId,
+		Title:        title,
+		CreatedAt:    now,
+		LastModified: now,
+	}
+
+	err := s.store.CreateChecklistItem(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordFor(ctx, models.ResourceTask, taskId, models.ResourceChecklist, item.Id, models.ActionCreated, diff(nil, checklistFields(item)))
+
+	return item, nil
+}
+
+func (s *WorkspaceService) GetChecklist(ctx context.Context, taskId uuid.UUID) ([]models.ChecklistItem, error) {
+	return s.store.GetChecklist(ctx, taskId)
+}
+
+// getChecklistItem returns an item of the task's checklist, reporting items
+// of other tasks as not found.
+func (s *WorkspaceService) getChecklistItem(ctx context.Context, taskId, itemId uuid.UUID) (*models.ChecklistItem, error) {
+	item, err := s.store.GetChecklistItem(ctx, itemId)
+	if err != nil {
+		return nil, err
+	} else if item.TaskId != taskId {
+		return nil, models.ErrNotFound
+	}
+
+	return item, nil
+}
+
+// UpdateChecklistItem changes the fields of a checklist item that are not nil.
+func (s *WorkspaceService) UpdateChecklistItem(ctx context.Context, taskId, itemId uuid.UUID, title *string, done *bool, position *int) (*models.ChecklistItem, error) {
+	item, err := s.getChecklistItem(ctx, taskId, itemId)
+	if err != nil {
+		return nil, err
+	}
+	before := checklistFields(item)
+
+	if title != nil {
+		item.Title = *title
+	}
+	if done != nil {
+		item.Done = *done
+	}
+	if position != nil {
+		item.Position = *position
+	}
+	item.LastModified = time.Now().UTC()
+
+	err = s.store.UpdateChecklistItem(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	if changes := diff(before, checklistFields(item)); len(changes) > 0 {
+		s.recordFor(ctx, models.ResourceTask, taskId, models.ResourceChecklist, item.Id, models.ActionUpdated, changes)
+	}
+
+	return item, nil
+}
+
+func (s *WorkspaceService) DeleteChecklistItem(ctx context.Context, taskId, itemId uuid.UUID) error {
+	item, err := s.getChecklistItem(ctx, taskId, itemId)
+	if err != nil {
+		return err
+	}
+
+	err = s.store.DeleteChecklistItem(ctx, item.Id)
+	if err != nil {
+		return err
+	}
+
+	s.recordFor(ctx, models.ResourceTask, taskId, models.ResourceChecklist, item.Id, models.ActionDeleted, diff(checklistFields(item), nil))
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+)
+
+// mentionPattern matches @handles where a handle is an email address or the
+// part of one before the '@'.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@.])@([\w.%+-]+(?:@[\w-]+(?:\.[\w-]+)+)?)`)
+
+// parseMentions returns the distinct, lower-cased handles mentioned in body.
+func parseMentions(body string) []string {
+	seen := map[string]bool{}
+	handles := []string{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		handle := strings.ToLower(strings.TrimRight(match[1], "."))
+		if handle == "" || seen[handle] {
+			continue
+		}
+		seen[handle] = true
+		handles = append(handles, handle)
+	}
+	return handles
+}
+
+// resolveMentions looks up the workspace members mentioned in body.
+func (s *WorkspaceService) resolveMentions(ctx context.Context, taskId uuid.UUID, body string) ([]models.User, error) {
+	handles := parseMentions(body)
+	if len(handles) == 0 {
+		return []models.User{}, nil
+	}
+
+	workspaceId, err := s.store.GetResourceWorkspace(ctx, models.ResourceTask, taskId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.GetMentionableUsers(ctx, workspaceId, handles)
+}
+
+func (s *WorkspaceService) AddComment(ctx context.Context, taskId, authorId, parentId uuid.UUID, body string) (*models.Comment, error) {
+	if parentId != uuid.Nil {
+		parent, err := s.store.GetComment(ctx, parentId)
+		if err != nil {
+			return nil, err
+		}
+		if parent.TaskId != taskId || parent.ParentId != uuid.Nil {
+			return nil, ErrNestedReply
+		}
+	}
+
+	mentions, err := s.resolveMentions(ctx, taskId, body)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	comment := &models.Comment{
+		Id:           uuid.New(),
+		TaskId:       taskId,
+		ParentId:     parentId,
+		Author:       &models.User{Id: authorId},
+		Body:         body,
+		Mentions:     mentions,
+		CreatedAt:    now,
+		LastModified: now,
+	}
+
+	err = s.store.CreateComment(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordFor(ctx, models.ResourceTask, taskId, models.ResourceComment, comment.Id, models.ActionCreated, diff(nil, fields{"body": body}))
+
+	return s.store.GetComment(ctx, comment.Id)
+}
+
+// GetTaskComments returns the top-level comments of a task with their
+// replies nested beneath them.
+func (s *WorkspaceService) GetTaskComments(ctx context.Context, taskId uuid.UUID) ([]models.Comment, error) {
+	comments, err := s.store.GetTaskComments(ctx, taskId)
+	if err != nil {
+		return nil, err
+	}
+
+	replies := map[uuid.UUID][]models.Comment{}
+	for _, comment := range comments {
+		if comment.ParentId != uuid.Nil {
+			replies[comment.ParentId] = append(replies[comment.ParentId], comment)
+		}
+	}
+
+	threads := []models.Comment{}
+	for _, comment := range comments {
+		if comment.ParentId == uuid.Nil {
+			comment.Replies = replies[comment.Id]
+			threads = append(threads, comment)
+		}
+	}
+
+	return threads, nil
+}
+
+// getTaskComment fetches a comment, treating comments of other tasks as missing.
+func (s *WorkspaceService) getTaskComment(ctx context.Context, taskId, commentId uuid.UUID) (*models.Comment, error) {
+	comment, err := s.store.GetComment(ctx, commentId)
+	if err != nil {
+		return nil, err
+	}
+	if comment.TaskId != taskId {
+		return nil, models.ErrNotFound
+	}
+	return comment, nil
+}
+
+// EditComment replaces the body of a comment. Only its author may edit it.
+func (s *WorkspaceService) EditComment(ctx context.Context, taskId, commentId, userId uuid.UUID, body string) (*models.Comment, error) {
+	comment, err := s.getTaskComment(ctx, taskId, commentId)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.Author.Id != userId {
+		return nil, ErrPermissionDenied
+	}
+
+	mentions, err := s.resolveMentions(ctx, taskId, body)
+	if err != nil {
+		return nil, err
+	}
+
+	previousBody := comment.Body
+	comment.Body = body
+	comment.Mentions = mentions
+	comment.LastModified = time.Now().UTC()
+
+	err = s.store.UpdateComment(ctx, comment, previousBody)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordFor(ctx, models.ResourceTask, taskId, models.ResourceComment, comment.Id, models.ActionUpdated, diff(fields{"body": previousBody}, fields{"body": body}))
+
+	return s.store.GetComment(ctx, comment.Id)
+}
+
+// DeleteComment removes a comment and its replies. Comments may be deleted by
+// their author or by a workspace admin.
+func (s *WorkspaceService) DeleteComment(ctx context.Context, taskId, commentId, userId uuid.UUID, role models.Role) error {
+	comment, err := s.getTaskComment(ctx, taskId, commentId)
+	if err != nil {
+		return err
+	}
+
+	if comment.Author.Id != userId && !role.Includes(models.RoleAdmin) {
+		return ErrPermissionDenied
+	}
+
+	err = s.store.DeleteComment(ctx, comment.Id)
+	if err != nil {
+		return err
+	}
+
+	s.recordFor(ctx, models.ResourceTask, taskId, models.ResourceComment, comment.Id, models.ActionDeleted, diff(fields{"body": comment.Body}, nil))
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{name: "no mentions", body: "looks good to me", want: []string{}},
+		{name: "local part", body: "@ama can you review?", want: []string{"ama"}},
+		{name: "email address", body: "cc @Kofi.Mensah@example.com.", want: []string{"kofi.mensah@example.com"}},
+		{name: "duplicates and punctuation", body: "@ama, @kofi and @AMA.", want: []string{"ama", "kofi"}},
+		{name: "plain email is not a mention", body: "mail ama@example.com", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseMentions(tt.body))
+		})
+	}
+}
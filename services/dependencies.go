@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+)
+
+func (s *WorkspaceService) GetDependencies(ctx context.Context, taskId uuid.UUID) (*models.TaskDependencies, error) {
+	deps, err := s.store.GetDependencies(ctx, taskId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.loadLabels(ctx, deps.BlockedBy); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadLabels(ctx, deps.Blocks); err != nil {
+		return nil, err
+	}
+
+	return deps, nil
+}
+
+// AddDependency marks taskId as blocked by blockerId. Both tasks must be in
+// the same workspace and the dependency must not close a cycle.
+func (s *WorkspaceService) AddDependency(ctx context.Context, taskId, blockerId uuid.UUID) error {
+	if taskId == blockerId {
+		return ErrDependencyCycle
+	}
+
+	workspaceId, err := s.store.GetResourceWorkspace(ctx, models.ResourceTask, taskId)
+	if err != nil {
+		return err
+	}
+
+	blockerWorkspaceId, err := s.store.GetResourceWorkspace(ctx, models.ResourceTask, blockerId)
+	if err != nil || blockerWorkspaceId != workspaceId {
+		return ErrInvalidBlocker
+	}
+
+	// dependencies of a workspace are added one at a time, so that two
+	// additions cannot each pass the cycle check and close a cycle together
+	err = s.store.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.store.LockDependencies(ctx, workspaceId); err != nil {
+			return ErrFailedOperation
+		}
+
+		cycle, err := s.store.IsBlockedBy(ctx, blockerId, taskId)
+		if err != nil {
+			return ErrFailedOperation
+		} else if cycle {
+			return ErrDependencyCycle
+		}
+
+		err = s.store.AddDependency(ctx, taskId, blockerId)
+		if err != nil {
+			if strings.Contains(err.Error(), "SQLSTATE 23505") {
+				return ErrDuplicateEntry
+			}
+			return ErrFailedOperation
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.recordFor(ctx, models.ResourceTask, taskId, models.ResourceTask, taskId, models.ActionAdded, diff(nil, fields{"blockedBy": blockerId.String()}))
+
+	return nil
+}
+
+func (s *WorkspaceService) RemoveDependency(ctx context.Context, taskId, blockerId uuid.UUID) error {
+	err := s.store.RemoveDependency(ctx, taskId, blockerId)
+	if err != nil {
+		return err
+	}
+
+	s.recordFor(ctx, models.ResourceTask, taskId, models.ResourceTask, taskId, models.ActionRemoved, diff(fields{"blockedBy": blockerId.String()}, nil))
+
+	return nil
+}
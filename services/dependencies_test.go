@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// dependencyStore holds the dependencies of a single workspace in memory and
+// records the order of the calls made to it.
+type dependencyStore struct {
+	models.WorkspaceStore
+	blockers map[uuid.UUID][]uuid.UUID
+	calls    []string
+}
+
+func (f *dependencyStore) GetResourceWorkspace(ctx context.Context, resource models.Resource, id uuid.UUID) (uuid.UUID, error) {
+	return uuid.Nil, nil
+}
+
+func (f *dependencyStore) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.calls = append(f.calls, "begin")
+	err := fn(ctx)
+	f.calls = append(f.calls, "end")
+	return err
+}
+
+func (f *dependencyStore) LockDependencies(ctx context.Context, workspaceId uuid.UUID) error {
+	f.calls = append(f.calls, "lock")
+	return nil
+}
+
+func (f *dependencyStore) IsBlockedBy(ctx context.Context, taskId, blockerId uuid.UUID) (bool, error) {
+	f.calls = append(f.calls, "check")
+	for _, id := range f.blockers[taskId] {
+		if blocked, _ := f.IsBlockedBy(ctx, id, blockerId); id == blockerId || blocked {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *dependencyStore) AddDependency(ctx context.Context, taskId, blockerId uuid.UUID) error {
+	f.calls = append(f.calls, "add")
+	f.blockers[taskId] = append(f.blockers[taskId], blockerId)
+	return nil
+}
+
+func (f *dependencyStore) InsertActivity(ctx context.Context, activity *models.Activity) error {
+	return nil
+}
+
+func TestAddDependency(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	store := &dependencyStore{blockers: map[uuid.UUID][]uuid.UUID{}}
+	s := &WorkspaceService{store: store}
+
+	err := s.AddDependency(context.Background(), a, b)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"begin", "lock", "check", "add", "end"}, store.calls, "the cycle check and insert run under the lock")
+
+	err = s.AddDependency(context.Background(), b, a)
+	assert.ErrorIs(t, err, ErrDependencyCycle)
+	assert.Equal(t, []uuid.UUID{b}, store.blockers[a])
+	assert.Empty(t, store.blockers[b])
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/primekobie/hazel/mail"
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+)
+
+const (
+	// DueSoonWindow is how long before a task is due its assignees are
+	// reminded of it.
+	DueSoonWindow = 24 * time.Hour
+	// DigestInterval is the least time between two digests sent to a user.
+	DigestInterval = 24 * time.Hour
+)
+
+// digestSections are the headings notifications are grouped under in a
+// digest, in the order they appear.
+var digestSections = []struct {
+	Type  models.NotificationType
+	Title string
+}{
+	{models.NotificationTaskDueSoon, "Due soon"},
+	{models.NotificationTaskAssigned, "Assigned to you"},
+	{models.NotificationTaskStatus, "Status changes"},
+	{models.NotificationTaskUpdated, "Other task updates"},
+	{models.NotificationMemberAdded, "Workspaces"},
+}
+
+type notificationData struct {
+	Address mail.Address
+	Summary string
+}
+
+type digestSection struct {
+	Title   string
+	Entries []string
+}
+
+type digestData struct {
+	Address  mail.Address
+	Count    int
+	Sections []digestSection
+}
+
+// describeNotification returns a sentence telling the recipient what
+// happened.
+func describeNotification(n *models.Notification) string {
+	actor := "Someone"
+	if n.Actor != nil && n.Actor.Name != "" {
+		actor = n.Actor.Name
+	}
+
+	switch n.Type {
+	case models.NotificationTaskAssigned:
+		return fmt.Sprintf("%s assigned you to “%s”", actor, n.Subject)
+	case models.NotificationTaskStatus:
+		if change, ok := n.Changes["status"]; ok {
+			return fmt.Sprintf("%s moved “%s” from %v to %v", actor, n.Subject, change.Before, change.After)
+		}
+		return fmt.Sprintf("%s changed the status of “%s”", actor, n.Subject)
+	case models.NotificationTaskUpdated:
+		fields := make([]string, 0, len(n.Changes))
+		for field := range n.Changes {
+			fields = append(fields, field)
+		}
+		slices.Sort(fields)
+		if len(fields) == 0 {
+			return fmt.Sprintf("%s updated “%s”", actor, n.Subject)
+		}
+		return fmt.Sprintf("%s updated the %s of “%s”", actor, strings.Join(fields, ", "), n.Subject)
+	case models.NotificationTaskDueSoon:
+		if change, ok := n.Changes["due"]; ok {
+			if due, err := time.Parse(time.RFC3339, fmt.Sprint(change.After)); err == nil {
+				return fmt.Sprintf("“%s” is due %s", n.Subject, due.UTC().Format("January 2 at 15:04 UTC"))
+			}
+		}
+		return fmt.Sprintf("“%s” is due soon", n.Subject)
+	case models.NotificationMemberAdded:
+		if change, ok := n.Changes["role"]; ok {
+			return fmt.Sprintf("You joined the %s workspace as %v", n.Subject, change.After)
+		}
+		return fmt.Sprintf("You joined the %s workspace", n.Subject)
+	}
+	return fmt.Sprintf("%s made a change to “%s”", actor, n.Subject)
+}
+
+// emailNotification queues an email of a single notification to user.
+func (s *WorkspaceService) emailNotification(ctx context.Context, user *models.User, n *models.Notification) error {
+	data := notificationData{
+		Address: mail.Address{Name: user.Name, Email: user.Email},
+		Summary: describeNotification(n),
+	}
+	return s.mail.send(ctx, []mail.Address{data.Address}, "notification.html", data)
+}
+
+// buildDigest groups notifications under the digest's section headings.
+func buildDigest(user *models.User, notifications []models.Notification) digestData {
+	data := digestData{
+		Address: mail.Address{Name: user.Name, Email: user.Email},
+		Count:   len(notifications),
+	}
+
+	for _, section := range digestSections {
+		entries := []string{}
+		for i := range notifications {
+			if notifications[i].Type == section.Type {
+				entries = append(entries, describeNotification(&notifications[i]))
+			}
+		}
+		if len(entries) > 0 {
+			data.Sections = append(data.Sections, digestSection{Title: section.Title, Entries: entries})
+		}
+	}
+
+	return data
+}
+
+// RunNotificationJobs reminds assignees of tasks due soon and sends pending
+// digests, then repeats every interval until ctx is done.
+func (s *WorkspaceService) RunNotificationJobs(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now().UTC()
+		s.remindDueTasks(ctx, now)
+		s.sendDigests(ctx, now)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// remindDueTasks notifies the assignees of tasks due within DueSoonWindow
+// who have not been reminded of them yet.
+func (s *WorkspaceService) remindDueTasks(ctx context.Context, now time.Time) {
+	reminders, err := s.store.GetDueReminders(ctx, now.Add(DueSoonWindow), DueSoonWindow)
+	if err != nil {
+		slog.Error("failed to get due reminders", "error", err)
+		return
+	}
+
+	for _, reminder := range reminders {
+		s.notify(ctx, []uuid.UUID{reminder.UserId}, models.Notification{
+			Type:        models.NotificationTaskDueSoon,
+			WorkspaceId: reminder.WorkspaceId,
+			EntityType:  models.ResourceTask,
+			EntityId:    reminder.Task.Id,
+			Subject:     reminder.Task.Title,
+			Changes:     diff(nil, fields{"due": timeField(reminder.Task.Due, time.RFC3339)}),
+		})
+	}
+}
+
+// sendDigests emails each user with pending notification emails who has not
+// had a digest in the last DigestInterval.
+func (s *WorkspaceService) sendDigests(ctx context.Context, now time.Time) {
+	recipients, err := s.store.GetDigestRecipients(ctx, now.Add(-DigestInterval))
+	if err != nil {
+		slog.Error("failed to get digest recipients", "error", err)
+		return
+	}
+
+	for _, userId := range recipients {
+		if ctx.Err() != nil {
+			return
+		}
+
+		err := s.sendDigest(ctx, userId, now)
+		if err != nil {
+			slog.Error("failed to send digest", "error", err, "user_id", userId)
+		}
+	}
+}
+
+func (s *WorkspaceService) sendDigest(ctx context.Context, userId uuid.UUID, now time.Time) error {
+	settings, err := s.store.GetNotificationSettings(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	notifications, err := s.store.GetPendingEmails(ctx, userId)
+	if err != nil {
+		return err
+	} else if len(notifications) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(notifications))
+	for i := range notifications {
+		ids[i] = notifications[i].Id
+	}
+
+	data := buildDigest(&settings.User, notifications)
+	return s.store.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.mail.send(ctx, []mail.Address{data.Address}, "notification_digest.html", data)
+		if err != nil {
+			return err
+		}
+
+		return s.store.MarkDigestSent(ctx, userId, ids, now)
+	})
+}
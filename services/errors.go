@@ -1,6 +1,11 @@
 package services
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/primekobie/hazel/models"
+)
 
 var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
@@ -10,4 +15,45 @@ var (
 	ErrInvalidPassword    = errors.New("password must be between 8 and 20 characters")
 	ErrInvalidDateFormat  = errors.New("the provided date format is not valid; expected: 'YYYY-MM-DD'")
 	ErrDuplicateEntry     = errors.New("an entry for this entity already exists")
+	ErrInvalidRole        = errors.New("role must be one of 'admin', 'member' or 'viewer'")
+	ErrPermissionDenied   = errors.New("you do not have permission to modify this resource")
+	ErrNestedReply        = errors.New("replies can only be made to top-level comments on the same task")
+	ErrOwnerRemoval       = errors.New("the workspace owner cannot leave or be removed")
+	ErrAlreadyMember      = errors.New("user is already a member of this workspace")
+	ErrInvitationClosed   = errors.New("invitation has already been answered, revoked or has expired")
+	ErrInvalidParent      = errors.New("parent must be a task in the same project")
+	ErrTaskCycle          = errors.New("a task cannot be nested under itself or one of its subtasks")
+	ErrTaskTooDeep        = fmt.Errorf("subtasks cannot be nested more than %d levels deep", models.MaxTaskDepth)
+	ErrInvalidBlocker     = errors.New("blocker must be a task in the same workspace")
+	ErrDependencyCycle    = errors.New("dependency would make the task block itself")
+	ErrTaskBlocked        = errors.New("task has open blockers; set force to complete it anyway")
+	ErrInvalidAssignee    = errors.New("tasks can only be assigned to members of their workspace")
+	ErrInvalidLabel       = errors.New("label must belong to the task's workspace")
+	ErrInvalidStatus      = errors.New("status must be one of the project's statuses")
+	ErrInvalidCategory    = errors.New("category must be one of 'open', 'in_progress' or 'done'")
+	ErrInvalidStatusKey   = errors.New("status key may only contain lowercase letters, digits, '-' and '_'")
+	ErrTransitionDenied   = errors.New("the project's workflow does not allow this status change")
+	ErrInvalidTransition  = errors.New("transitions must be between two different statuses of the project")
+	ErrStatusInUse        = errors.New("status is still used by tasks")
+	ErrLastStatus         = errors.New("a project must keep at least one status")
+	ErrInvalidSprint      = errors.New("sprint must be an open sprint of the task's project")
+	ErrSprintState        = errors.New("operation is not allowed in the sprint's current state")
+	ErrSprintActive       = errors.New("project already has an active sprint")
+	ErrInvalidDateRange   = errors.New("end date cannot be before start date")
+	ErrInvalidMilestone   = errors.New("milestone must belong to the task's project")
+	ErrInvalidPosition    = errors.New("tasks can only be placed next to tasks of their status column")
+	ErrFileTooLarge       = errors.New("file is larger than the upload limit")
+	ErrFileType           = errors.New("files of this type cannot be uploaded")
+	ErrTimerRunning       = errors.New("a timer is already running; stop it before starting another")
+	ErrNoRunningTimer     = errors.New("no timer is running on this task")
+	ErrEntryRunning       = errors.New("the duration of a running timer cannot be changed")
+	ErrInvalidDuration    = errors.New("duration must be a positive number of seconds of at most 24 hours")
+	ErrInvalidEstimate    = errors.New("estimate must be a whole, non-negative number of seconds")
+	ErrInvalidSearch      = errors.New("search query must be between 1 and 200 characters")
+	ErrInvalidSearchType  = errors.New("search type must be 'task' or 'project'")
+	ErrInvalidViewFilter  = errors.New("view filter has an unknown sort or priority, a page size over 100, or both mine and an assignee")
+	ErrInvalidViewProject = errors.New("view project must belong to the view's workspace")
+	ErrInvalidGrouping    = errors.New("groupBy must be one of 'status', 'priority', 'sprint' or 'milestone'")
+	ErrNotificationType   = errors.New("unknown notification type")
+	ErrEmailDelivery      = errors.New("delivery must be 'immediate' or 'digest'")
 )
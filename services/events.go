@@ -0,0 +1,29 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/primekobie/hazel/events"
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+)
+
+// EventType returns the name of the event published for an action on an
+// entity, e.g. "task.updated".
+func EventType(entityType models.Resource, action string) string {
+	return fmt.Sprintf("%s.%s", entityType, action)
+}
+
+// publish sends the activity to the workspace's event stream.
+func (s *WorkspaceService) publish(activity *models.Activity) {
+	if s.events == nil {
+		return
+	}
+	s.events.Publish(activity.WorkspaceId, EventType(activity.EntityType, activity.Action), activity)
+}
+
+// SubscribeEvents streams the changes made in a workspace. See
+// events.Bus.Subscribe for the meaning of lastId and the returned values.
+func (s *WorkspaceService) SubscribeEvents(workspaceId uuid.UUID, lastId uint64) (*events.Subscription, []events.Event, bool) {
+	return s.events.Subscribe(workspaceId, lastId)
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/primekobie/hazel/storage"
+)
+
+const (
+	// MaxAttachmentSize is the largest file that can be attached to a task.
+	MaxAttachmentSize = 25 << 20
+	// MaxPhotoSize is the largest profile photo a user can upload.
+	MaxPhotoSize = 5 << 20
+)
+
+// photoTypes are the content types a profile photo may have.
+var photoTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// attachmentTypes are the content types a task attachment may have. Office
+// documents are zip archives and are detected as such.
+var attachmentTypes = map[string]bool{
+	"image/png":          true,
+	"image/jpeg":         true,
+	"image/gif":          true,
+	"image/webp":         true,
+	"image/bmp":          true,
+	"application/pdf":    true,
+	"application/zip":    true,
+	"application/x-gzip": true,
+	"application/json":   true,
+	"text/plain":         true,
+	"text/csv":           true,
+	"video/mp4":          true,
+	"video/webm":         true,
+	"audio/mpeg":         true,
+}
+
+// sniff detects the content type of the file read from r from its first
+// bytes. It returns the content type and a reader that yields the whole file.
+func sniff(r io.Reader) (string, io.Reader, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", nil, err
+	}
+	head = head[:n]
+
+	return http.DetectContentType(head), io.MultiReader(bytes.NewReader(head), r), nil
+}
+
+// allowedType reports whether the media type of contentType, ignoring its
+// parameters, is one of types.
+func allowedType(contentType string, types map[string]bool) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && types[mediaType]
+}
+
+// putFile stores the file read from r under key, rejecting files larger than
+// limit bytes, and returns its size.
+func putFile(ctx context.Context, files storage.Storage, key string, r io.Reader, limit int64) (int64, error) {
+	size, err := files.Put(ctx, key, io.LimitReader(r, limit+1))
+	if err != nil {
+		slog.Error("failed to store file", "key", key, "error", err.Error())
+		return 0, ErrFailedOperation
+	}
+
+	if size > limit {
+		deleteFile(ctx, files, key)
+		return 0, ErrFileTooLarge
+	}
+
+	return size, nil
+}
+
+// deleteFile removes a stored file, logging rather than returning failures
+// since the record pointing to it is already gone.
+func deleteFile(ctx context.Context, files storage.Storage, key string) {
+	if err := files.Delete(ctx, key); err != nil {
+		slog.Error("failed to delete stored file", "key", key, "error", err.Error())
+	}
+}
+
+// openFile opens a stored file, reporting missing files as not found.
+func openFile(ctx context.Context, files storage.Storage, key string) (io.ReadCloser, error) {
+	r, err := files.Open(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			slog.Error("stored file is missing", "key", key)
+			return nil, models.ErrNotFound
+		}
+		slog.Error("failed to open stored file", "key", key, "error", err.Error())
+		return nil, ErrFailedOperation
+	}
+
+	return r, nil
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/primekobie/hazel/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSniff(t *testing.T) {
+	png := append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 1024)...)
+
+	contentType, r, err := sniff(bytes.NewReader(png))
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", contentType)
+	assert.True(t, allowedType(contentType, photoTypes))
+
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, png, data)
+
+	contentType, _, err = sniff(strings.NewReader("<html><body>hello</body></html>"))
+	require.NoError(t, err)
+	assert.False(t, allowedType(contentType, attachmentTypes))
+
+	contentType, _, err = sniff(strings.NewReader("notes"))
+	require.NoError(t, err)
+	assert.True(t, allowedType(contentType, attachmentTypes))
+	assert.False(t, allowedType(contentType, photoTypes))
+}
+
+func TestPutFile(t *testing.T) {
+	ctx := context.Background()
+	files, err := storage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	size, err := putFile(ctx, files, "tasks/a", strings.NewReader("12345"), 5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), size)
+
+	_, err = putFile(ctx, files, "tasks/b", strings.NewReader("123456"), 5)
+	assert.ErrorIs(t, err, ErrFileTooLarge)
+
+	_, err = files.Open(ctx, "tasks/b")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
@@ -1,14 +1,19 @@
 package services
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
-	"log/slog"
 	"math/big"
+	"time"
 
 	"github.com/primekobie/hazel/mail"
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
 )
 
 // GenerateOTP generates a 6-digit OTP as a string
@@ -18,19 +23,52 @@ func generateOTP() string {
 	return fmt.Sprintf("%06d", n.Int64())
 }
 
+// generateResetToken generates a random, URL-safe password reset token. At
+// 256 bits it cannot be guessed within its lifetime, and two users are never
+// issued the same token.
+func generateResetToken() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
 // hashString hashes the token using SHA-256 and returns the hex-encoded hash
 func hashString(token string) string {
 	hash := sha256.Sum256([]byte(token))
 	return hex.EncodeToString(hash[:])
 }
 
-func (us *UserService) sendEmail(recipients []mail.Address, template string, data any) {
-	// send email
-	go func() {
-		err := us.mail.Send(recipients, template, data)
-		if err != nil {
-			slog.Error("failed  to send email", "error", err)
-			return
-		}
-	}()
+// mailQueue composes templated emails and adds them to the outbox, from
+// which a MailWorker delivers them.
+type mailQueue struct {
+	mailer *mail.Mailer
+	outbox models.OutboxStore
+}
+
+func newMailQueue(mailer *mail.Mailer, outbox models.OutboxStore) *mailQueue {
+	return &mailQueue{mailer: mailer, outbox: outbox}
+}
+
+// send queues an email. Queued with a context carrying a transaction, the
+// email is only delivered if the transaction commits.
+func (q *mailQueue) send(ctx context.Context, recipients []mail.Address, template string, data any) error {
+	msg, err := q.mailer.Compose(recipients, template, data)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	return q.outbox.EnqueueMail(ctx, &models.OutboxMail{
+		Id:            uuid.New(),
+		Message:       payload,
+		Status:        models.MailPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	})
 }
@@ -0,0 +1,18 @@
+package services
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateResetToken(t *testing.T) {
+	token := generateResetToken()
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	require.NoError(t, err)
+	assert.Len(t, raw, 32)
+	assert.NotEqual(t, token, generateResetToken())
+}
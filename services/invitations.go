@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/primekobie/hazel/auth"
+	"github.com/primekobie/hazel/mail"
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+)
+
+// invitationTTL is how long an invitation can be accepted for.
+const invitationTTL = 7 * 24 * time.Hour
+
+// invitationData is rendered by the invitation email template.
+type invitationData struct {
+	Address   mail.Address
+	Workspace string
+	InvitedBy string
+	Role      models.Role
+	Code      uuid.UUID
+	ExpiresAt string
+}
+
+// InviteMember invites an email address to join a workspace with the given
+// role. Inviting the same address again replaces its pending invitation.
+func (s *WorkspaceService) InviteMember(ctx context.Context, workspaceId, inviterId uuid.UUID, email string, role models.Role) (*models.Invitation, error) {
+	if !role.Valid() || role == models.RoleOwner {
+		return nil, ErrInvalidRole
+	}
+
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	isMember, err := s.store.HasMemberWithEmail(ctx, workspaceId, email)
+	if err != nil {
+		return nil, ErrFailedOperation
+	} else if isMember {
+		return nil, ErrAlreadyMember
+	}
+
+	now := time.Now().UTC()
+	invitation := &models.Invitation{
+		Id:        uuid.New(),
+		Workspace: &models.Workspace{Id: workspaceId},
+		Email:     email,
+		Role:      role,
+		InvitedBy: &models.User{Id: inviterId},
+		Status:    models.InvitationPending,
+		CreatedAt: now,
+		ExpiresAt: now.Add(invitationTTL),
+	}
+
+	err = s.store.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.store.CreateInvitation(ctx, invitation)
+		if err != nil {
+			return ErrFailedOperation
+		}
+
+		invitation, err = s.store.GetInvitation(ctx, invitation.Id)
+		if err != nil {
+			return err
+		}
+
+		data := invitationData{
+			Address:   mail.Address{Email: email},
+			Workspace: invitation.Workspace.Name,
+			Role:      role,
+			Code:      invitation.Id,
+			ExpiresAt: invitation.ExpiresAt.Format("January 2, 2006"),
+		}
+		if invitation.InvitedBy != nil {
+			data.InvitedBy = invitation.InvitedBy.Name
+		}
+		return s.mail.send(ctx, []mail.Address{data.Address}, "workspace_invitation.html", data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, workspaceId, models.ResourceInvitation, invitation.Id, models.ActionCreated, diff(nil, fields{"email": email, "role": string(role)}))
+
+	return invitation, nil
+}
+
+func (s *WorkspaceService) GetWorkspaceInvitations(ctx context.Context, workspaceId uuid.UUID) ([]models.Invitation, error) {
+	return s.store.GetWorkspaceInvitations(ctx, workspaceId)
+}
+
+// RevokeInvitation withdraws a pending invitation to the workspace.
+func (s *WorkspaceService) RevokeInvitation(ctx context.Context, workspaceId, invitationId uuid.UUID) error {
+	invitation, err := s.store.GetInvitation(ctx, invitationId)
+	if err != nil {
+		return err
+	} else if invitation.Workspace.Id != workspaceId {
+		return models.ErrNotFound
+	}
+
+	invitation.Status = models.InvitationRevoked
+	invitation.RespondedAt = time.Now().UTC()
+
+	err = s.store.CloseInvitation(ctx, invitation)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return ErrInvitationClosed
+		}
+		return ErrFailedOperation
+	}
+
+	s.record(ctx, workspaceId, models.ResourceInvitation, invitation.Id, models.ActionRevoked, nil)
+
+	return nil
+}
+
+// GetUserInvitations returns the pending invitations sent to the user's email.
+func (s *WorkspaceService) GetUserInvitations(ctx context.Context, user *models.User) ([]models.Invitation, error) {
+	return s.store.GetPendingInvitations(ctx, user.Email)
+}
+
+// RespondToInvitation accepts or declines an invitation on behalf of the user
+// it was sent to. Invitations sent to other addresses are reported as not
+// found.
+func (s *WorkspaceService) RespondToInvitation(ctx context.Context, invitationId uuid.UUID, user *models.User, accept bool) (*models.Invitation, error) {
+	invitation, err := s.store.GetInvitation(ctx, invitationId)
+	if err != nil {
+		return nil, err
+	} else if !strings.EqualFold(invitation.Email, user.Email) {
+		return nil, models.ErrNotFound
+	}
+
+	if accept {
+		err = s.acceptInvitation(ctx, invitation, user.Id)
+	} else {
+		err = s.declineInvitation(ctx, invitation)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+func (s *WorkspaceService) acceptInvitation(ctx context.Context, invitation *models.Invitation, userId uuid.UUID) error {
+	invitation.Status = models.InvitationAccepted
+	invitation.RespondedAt = time.Now().UTC()
+
+	err := s.store.AcceptInvitation(ctx, invitation, userId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return ErrInvitationClosed
+		} else if strings.Contains(err.Error(), "SQLSTATE 23505") {
+			return ErrAlreadyMember
+		}
+		return ErrFailedOperation
+	}
+
+	s.record(ctx, invitation.Workspace.Id, models.ResourceMember, userId, models.ActionAdded, diff(nil, fields{"role": string(invitation.Role)}))
+	s.notify(ctx, []uuid.UUID{userId}, models.Notification{
+		Type:        models.NotificationMemberAdded,
+		WorkspaceId: invitation.Workspace.Id,
+		Actor:       invitation.InvitedBy,
+		EntityType:  models.ResourceWorkspace,
+		EntityId:    invitation.Workspace.Id,
+		Subject:     invitation.Workspace.Name,
+		Changes:     diff(nil, fields{"role": string(invitation.Role)}),
+	})
+
+	return nil
+}
+
+func (s *WorkspaceService) declineInvitation(ctx context.Context, invitation *models.Invitation) error {
+	invitation.Status = models.InvitationDeclined
+	invitation.RespondedAt = time.Now().UTC()
+
+	err := s.store.CloseInvitation(ctx, invitation)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return ErrInvitationClosed
+		}
+		return ErrFailedOperation
+	}
+
+	s.record(ctx, invitation.Workspace.Id, models.ResourceInvitation, invitation.Id, models.ActionDeclined, nil)
+
+	return nil
+}
+
+// JoinInvitedWorkspaces accepts every pending invitation sent to a newly
+// registered user's email once they have proven they own it.
+func (s *WorkspaceService) JoinInvitedWorkspaces(ctx context.Context, user *models.User) {
+	invitations, err := s.store.GetPendingInvitations(ctx, user.Email)
+	if err != nil {
+		slog.Error("failed to get pending invitations", "error", err, "user_id", user.Id)
+		return
+	}
+
+	ctx = auth.WithUserID(ctx, user.Id)
+	for i := range invitations {
+		err := s.acceptInvitation(ctx, &invitations[i], user.Id)
+		if err != nil {
+			slog.Error("failed to accept invitation", "error", err, "invitation_id", invitations[i].Id)
+		}
+	}
+}
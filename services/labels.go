@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+)
+
+func labelFields(label *models.Label) fields {
+	return fields{
+		"name":  label.Name,
+		"color": label.Color,
+	}
+}
+
+func (s *WorkspaceService) CreateLabel(ctx context.Context, workspaceId uuid.UUID, name, color string) (*models.Label, error) {
+	now := time.Now().UTC()
+	label := &models.Label{
+		Id:           uuid.New(),
+		WorkspaceId:  workspaceId,
+		Name:         strings.TrimSpace(name),
+		Color:        strings.ToLower(color),
+		CreatedAt:    now,
+		LastModified: now,
+	}
+
+	err := s.store.CreateLabel(ctx, label)
+	if err != nil {
+		if strings.Contains(err.Error(), "SQLSTATE 23505") {
+			return nil, ErrDuplicateEntry
+		}
+		return nil, ErrFailedOperation
+	}
+
+	s.record(ctx, workspaceId, models.ResourceLabel, label.Id, models.ActionCreated, diff(nil, labelFields(label)))
+
+	return label, nil
+}
+
+func (s *WorkspaceService) GetWorkspaceLabels(ctx context.Context, workspaceId uuid.UUID) ([]models.Label, error) {
+	return s.store.GetWorkspaceLabels(ctx, workspaceId)
+}
+
+// getWorkspaceLabel returns a label of the workspace, reporting labels of
+// other workspaces as not found.
+func (s *WorkspaceService) getWorkspaceLabel(ctx context.Context, workspaceId, labelId uuid.UUID) (*models.Label, error) {
+	label, err := s.store.GetLabel(ctx, labelId)
+	if err != nil {
+		return nil, err
+	} else if label.WorkspaceId != workspaceId {
+		return nil, models.ErrNotFound
+	}
+
+	return label, nil
+}
+
+// UpdateLabel changes the fields of a label that are not nil.
+func (s *WorkspaceService) UpdateLabel(ctx context.Context, workspaceId, labelId uuid.UUID, name, color *string) (*models.Label, error) {
+	label, err := s.getWorkspaceLabel(ctx, workspaceId, labelId)
+	if err != nil {
+		return nil, err
+	}
+	before := labelFields(label)
+
+	if name != nil {
+		label.Name = strings.TrimSpace(*name)
+	}
+	if color != nil {
+		label.Color = strings.ToLower(*color)
+	}
+	label.LastModified = time.Now().UTC()
+
+	err = s.store.UpdateLabel(ctx, label)
+	if err != nil {
+		if strings.Contains(err.Error(), "SQLSTATE 23505") {
+			return nil, ErrDuplicateEntry
+		}
+		return nil, ErrFailedOperation
+	}
+
+	if changes := diff(before, labelFields(label)); len(changes) > 0 {
+		s.record(ctx, workspaceId, models.ResourceLabel, label.Id, models.ActionUpdated, changes)
+	}
+
+	return label, nil
+}
+
+func (s *WorkspaceService) DeleteLabel(ctx context.Context, workspaceId, labelId uuid.UUID) error {
+	label, err := s.getWorkspaceLabel(ctx, workspaceId, labelId)
+	if err != nil {
+		return err
+	}
+
+	err = s.store.DeleteLabel(ctx, label.Id)
+	if err != nil {
+		return err
+	}
+
+	s.record(ctx, workspaceId, models.ResourceLabel, label.Id, models.ActionDeleted, diff(labelFields(label), nil))
+
+	return nil
+}
+
+// AttachLabel adds a label of the task's workspace to the task.
+func (s *WorkspaceService) AttachLabel(ctx context.Context, taskId, labelId uuid.UUID) error {
+	workspaceId, err := s.store.GetResourceWorkspace(ctx, models.ResourceTask, taskId)
+	if err != nil {
+		return err
+	}
+
+	label, err := s.getWorkspaceLabel(ctx, workspaceId, labelId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return ErrInvalidLabel
+		}
+		return err
+	}
+
+	err = s.store.AttachLabel(ctx, taskId, label.Id)
+	if err != nil {
+		if strings.Contains(err.Error(), "SQLSTATE 23505") {
+			return ErrDuplicateEntry
+		}
+		return ErrFailedOperation
+	}
+
+	s.record(ctx, workspaceId, models.ResourceTask, taskId, models.ActionAdded, diff(nil, fields{"label": label.Name}))
+
+	return nil
+}
+
+// DetachLabel removes a label of the task's workspace from the task.
+func (s *WorkspaceService) DetachLabel(ctx context.Context, taskId, labelId uuid.UUID) error {
+	workspaceId, err := s.store.GetResourceWorkspace(ctx, models.ResourceTask, taskId)
+	if err != nil {
+		return err
+	}
+
+	label, err := s.getWorkspaceLabel(ctx, workspaceId, labelId)
+	if err != nil {
+		return err
+	}
+
+	err = s.store.DetachLabel(ctx, taskId, label.Id)
+	if err != nil {
+		return err
+	}
+
+	s.record(ctx, workspaceId, models.ResourceTask, taskId, models.ActionRemoved, diff(fields{"label": label.Name}, nil))
+
+	return nil
+}
+
+// loadLabels fills in the labels of tasks.
+func (s *WorkspaceService) loadLabels(ctx context.Context, tasks []models.Task) error {
+	ids := make([]uuid.UUID, len(tasks))
+	for i := range tasks {
+		ids[i] = tasks[i].Id
+	}
+
+	labels, err := s.store.GetLabelsForTasks(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range tasks {
+		tasks[i].Labels = labels[tasks[i].Id]
+		if tasks[i].Labels == nil {
+			tasks[i].Labels = []models.Label{}
+		}
+	}
+
+	return nil
+}
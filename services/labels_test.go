@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// labelStore serves tasks of a single workspace and a set of labels, and
+// records the labels detached through it.
+type labelStore struct {
+	models.WorkspaceStore
+	workspaceId uuid.UUID
+	labels      map[uuid.UUID]models.Label
+	detached    []uuid.UUID
+}
+
+func (f *labelStore) GetResourceWorkspace(ctx context.Context, resource models.Resource, id uuid.UUID) (uuid.UUID, error) {
+	return f.workspaceId, nil
+}
+
+func (f *labelStore) GetLabel(ctx context.Context, id uuid.UUID) (*models.Label, error) {
+	label, ok := f.labels[id]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return &label, nil
+}
+
+func (f *labelStore) DetachLabel(ctx context.Context, taskId, labelId uuid.UUID) error {
+	f.detached = append(f.detached, labelId)
+	return nil
+}
+
+func (f *labelStore) InsertActivity(ctx context.Context, activity *models.Activity) error {
+	return nil
+}
+
+func TestDetachLabel(t *testing.T) {
+	workspaceId := uuid.New()
+	label := models.Label{Id: uuid.New(), WorkspaceId: workspaceId, Name: "bug"}
+	otherLabel := models.Label{Id: uuid.New(), WorkspaceId: uuid.New(), Name: "bug"}
+
+	store := &labelStore{
+		workspaceId: workspaceId,
+		labels:      map[uuid.UUID]models.Label{label.Id: label, otherLabel.Id: otherLabel},
+	}
+	s := &WorkspaceService{store: store}
+
+	err := s.DetachLabel(context.Background(), uuid.New(), otherLabel.Id)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+	assert.Empty(t, store.detached)
+
+	err = s.DetachLabel(context.Background(), uuid.New(), label.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{label.Id}, store.detached)
+}
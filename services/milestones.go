@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+)
+
+func milestoneFields(milestone *models.Milestone) fields {
+	return fields{
+		"title":       milestone.Title,
+		"description": milestone.Description,
+		"due":         timeField(milestone.Due.Time, models.DateLayout),
+	}
+}
+
+// rollUp derives the completion percentage of a milestone from its task
+// counts and flags it as overdue once its due date has passed while it is
+// incomplete. A milestone is due until the end of its due date in UTC.
+func rollUp(milestone *models.Milestone, now time.Time) {
+	milestone.Percent = 0
+	if milestone.Tasks > 0 {
+		milestone.Percent = milestone.TasksDone * 100 / milestone.Tasks
+	}
+
+	complete := milestone.Tasks > 0 && milestone.TasksDone == milestone.Tasks
+	milestone.Overdue = !milestone.Due.IsZero() && !complete && !now.Before(milestone.Due.AddDate(0, 0, 1))
+}
+
+func (s *WorkspaceService) CreateMilestone(ctx context.Context, projectId uuid.UUID, title, description string, due models.Date) (*models.Milestone, error) {
+	now := time.Now().UTC()
+	milestone := &models.Milestone{
+		Id:           uuid.New(),
+		ProjectId:    projectId,
+		Title:        strings.TrimSpace(title),
+		Description:  description,
+		Due:          due,
+		CreatedAt:    now,
+		LastModified: now,
+	}
+
+	err := s.store.CreateMilestone(ctx, milestone)
+	if err != nil {
+		return nil, err
+	}
+	rollUp(milestone, now)
+
+	s.recordFor(ctx, models.ResourceProject, projectId, models.ResourceMilestone, milestone.Id, models.ActionCreated, diff(nil, milestoneFields(milestone)))
+
+	return milestone, nil
+}
+
+func (s *WorkspaceService) GetProjectMilestones(ctx context.Context, projectId uuid.UUID) ([]models.Milestone, error) {
+	milestones, err := s.store.GetProjectMilestones(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range milestones {
+		rollUp(&milestones[i], now)
+	}
+
+	return milestones, nil
+}
+
+// GetMilestone returns a milestone of the project, reporting milestones of
+// other projects as not found.
+func (s *WorkspaceService) GetMilestone(ctx context.Context, projectId, milestoneId uuid.UUID) (*models.Milestone, error) {
+	milestone, err := s.store.GetMilestone(ctx, milestoneId)
+	if err != nil {
+		return nil, err
+	} else if milestone.ProjectId != projectId {
+		return nil, models.ErrNotFound
+	}
+	rollUp(milestone, time.Now())
+
+	return milestone, nil
+}
+
+// UpdateMilestone changes the fields of a milestone that are not nil.
+func (s *WorkspaceService) UpdateMilestone(ctx context.Context, projectId, milestoneId uuid.UUID, title, description *string, due *models.Date) (*models.Milestone, error) {
+	milestone, err := s.GetMilestone(ctx, projectId, milestoneId)
+	if err != nil {
+		return nil, err
+	}
+	before := milestoneFields(milestone)
+
+	if title != nil {
+		milestone.Title = strings.TrimSpace(*title)
+	}
+	if description != nil {
+		milestone.Description = *description
+	}
+	if due != nil {
+		milestone.Due = *due
+	}
+	milestone.LastModified = time.Now().UTC()
+
+	err = s.store.UpdateMilestone(ctx, milestone)
+	if err != nil {
+		return nil, err
+	}
+	rollUp(milestone, milestone.LastModified)
+
+	if changes := diff(before, milestoneFields(milestone)); len(changes) > 0 {
+		s.recordFor(ctx, models.ResourceProject, projectId, models.ResourceMilestone, milestone.Id, models.ActionUpdated, changes)
+	}
+
+	return milestone, nil
+}
+
+// DeleteMilestone removes a milestone, unlinking its tasks.
+func (s *WorkspaceService) DeleteMilestone(ctx context.Context, projectId, milestoneId uuid.UUID) error {
+	milestone, err := s.GetMilestone(ctx, projectId, milestoneId)
+	if err != nil {
+		return err
+	}
+
+	err = s.store.DeleteMilestone(ctx, milestone.Id)
+	if err != nil {
+		return err
+	}
+
+	s.recordFor(ctx, models.ResourceProject, projectId, models.ResourceMilestone, milestone.Id, models.ActionDeleted, diff(milestoneFields(milestone), nil))
+
+	return nil
+}
+
+// validateMilestone checks that tasks of the project can be linked to the
+// milestone milestoneId.
+func (s *WorkspaceService) validateMilestone(ctx context.Context, projectId, milestoneId uuid.UUID) error {
+	_, err := s.GetMilestone(ctx, projectId, milestoneId)
+	if errors.Is(err, models.ErrNotFound) {
+		return ErrInvalidMilestone
+	}
+	return err
+}
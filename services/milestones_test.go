@@ -0,0 +1,39 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollUp(t *testing.T) {
+	due := models.Date{Time: time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)}
+	onDueDate := time.Date(2025, time.March, 10, 23, 59, 0, 0, time.UTC)
+	dayAfter := time.Date(2025, time.March, 11, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		milestone   models.Milestone
+		now         time.Time
+		wantPercent int
+		wantOverdue bool
+	}{
+		{name: "no tasks", milestone: models.Milestone{Due: due}, now: onDueDate, wantPercent: 0, wantOverdue: false},
+		{name: "in progress on due date", milestone: models.Milestone{Due: due, Tasks: 3, TasksDone: 1}, now: onDueDate, wantPercent: 33, wantOverdue: false},
+		{name: "in progress after due date", milestone: models.Milestone{Due: due, Tasks: 3, TasksDone: 2}, now: dayAfter, wantPercent: 66, wantOverdue: true},
+		{name: "empty after due date", milestone: models.Milestone{Due: due}, now: dayAfter, wantPercent: 0, wantOverdue: true},
+		{name: "complete after due date", milestone: models.Milestone{Due: due, Tasks: 2, TasksDone: 2}, now: dayAfter, wantPercent: 100, wantOverdue: false},
+		{name: "no due date", milestone: models.Milestone{Tasks: 4, TasksDone: 1}, now: dayAfter, wantPercent: 25, wantOverdue: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			milestone := tt.milestone
+			rollUp(&milestone, tt.now)
+			assert.Equal(t, tt.wantPercent, milestone.Percent)
+			assert.Equal(t, tt.wantOverdue, milestone.Overdue)
+		})
+	}
+}
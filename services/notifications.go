@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/primekobie/hazel/auth"
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+)
+
+// notify creates a copy of notification for each recipient other than the
+// user whose action caused it, following each recipient's preferences for
+// its type. Recipients who are not members of the notification's workspace,
+// such as removed members who are still assigned to tasks, are skipped. The
+// actor defaults to the authenticated user. Like activity records, failures
+// are logged rather than returned.
+func (s *WorkspaceService) notify(ctx context.Context, recipients []uuid.UUID, notification models.Notification) {
+	if notification.Actor == nil {
+		if actorId, ok := auth.UserIDFromContext(ctx); ok {
+			notification.Actor = &models.User{Id: actorId}
+		}
+	}
+
+	for _, userId := range recipients {
+		if notification.Actor != nil && notification.Actor.Id == userId {
+			continue
+		}
+
+		if _, err := s.store.GetMemberRole(ctx, notification.WorkspaceId, userId); err != nil {
+			if !errors.Is(err, models.ErrNotFound) {
+				slog.Error("failed to check notification recipient membership", "error", err, "user_id", userId)
+			}
+			continue
+		}
+
+		settings, err := s.store.GetNotificationSettings(ctx, userId)
+		if err != nil {
+			slog.Error("failed to get notification settings", "error", err, "user_id", userId)
+			continue
+		}
+
+		pref := settings.Preference(notification.Type)
+		if !pref.InApp && !pref.Email {
+			continue
+		}
+
+		n := notification
+		n.Id = uuid.New()
+		n.UserId = userId
+		n.InApp = pref.InApp
+		n.EmailPending = pref.Email && settings.Delivery == models.DeliveryDigest
+		n.CreatedAt = time.Now().UTC()
+
+		err = s.store.WithinTx(ctx, func(ctx context.Context) error {
+			err := s.store.CreateNotification(ctx, &n)
+			if err != nil {
+				return err
+			}
+
+			if pref.Email && settings.Delivery == models.DeliveryImmediate {
+				return s.emailNotification(ctx, &settings.User, &n)
+			}
+			return nil
+		})
+		if err != nil {
+			slog.Error("failed to create notification", "error", err, "type", n.Type, "user_id", userId)
+		}
+	}
+}
+
+// notifyTask notifies recipients about a change to task.
+func (s *WorkspaceService) notifyTask(ctx context.Context, task *models.Task, notificationType models.NotificationType, recipients []uuid.UUID, changes map[string]models.Change) {
+	if len(recipients) == 0 {
+		return
+	}
+
+	workspaceId, err := s.store.GetResourceWorkspace(ctx, models.ResourceProject, task.Project.Id)
+	if err != nil {
+		slog.Error("failed to resolve workspace for notification", "error", err, "task_id", task.Id)
+		return
+	}
+
+	s.notify(ctx, recipients, models.Notification{
+		Type:        notificationType,
+		WorkspaceId: workspaceId,
+		EntityType:  models.ResourceTask,
+		EntityId:    task.Id,
+		Subject:     task.Title,
+		Changes:     changes,
+	})
+}
+
+// notifyAssignees notifies the users assigned to task that it changed,
+// telling status changes apart from other updates.
+func (s *WorkspaceService) notifyAssignees(ctx context.Context, task *models.Task, changes map[string]models.Change) {
+	assignees, err := s.store.GetAssignedUsers(ctx, task.Id)
+	if err != nil {
+		slog.Error("failed to get assignees for notification", "error", err, "task_id", task.Id)
+		return
+	}
+
+	recipients := make([]uuid.UUID, len(assignees))
+	for i, user := range assignees {
+		recipients[i] = user.Id
+	}
+
+	notificationType := models.NotificationTaskUpdated
+	if _, ok := changes["status"]; ok {
+		notificationType = models.NotificationTaskStatus
+	}
+
+	s.notifyTask(ctx, task, notificationType, recipients, changes)
+}
+
+// GetNotifications returns a page of the user's notifications, newest first
+// unless another order is requested, and their unread count.
+func (s *WorkspaceService) GetNotifications(ctx context.Context, userId uuid.UUID, filter models.NotificationFilter) (*models.NotificationPage, error) {
+	page, err := s.store.GetNotifications(ctx, userId, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := s.store.CountUnreadNotifications(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.NotificationPage{Page: *page, Unread: unread}, nil
+}
+
+// MarkNotificationRead marks one of the user's notifications read.
+func (s *WorkspaceService) MarkNotificationRead(ctx context.Context, userId, notificationId uuid.UUID) error {
+	return s.store.MarkNotificationRead(ctx, userId, notificationId, time.Now().UTC())
+}
+
+// MarkAllNotificationsRead marks all of the user's notifications read and
+// returns how many were unread.
+func (s *WorkspaceService) MarkAllNotificationsRead(ctx context.Context, userId uuid.UUID) (int64, error) {
+	return s.store.MarkAllNotificationsRead(ctx, userId, time.Now().UTC())
+}
+
+func (s *WorkspaceService) GetNotificationSettings(ctx context.Context, userId uuid.UUID) (*models.NotificationSettings, error) {
+	return s.store.GetNotificationSettings(ctx, userId)
+}
+
+// UpdateNotificationSettings changes how the user receives notification
+// emails if delivery is not nil, and saves the given preferences, leaving
+// those of types not included unchanged.
+func (s *WorkspaceService) UpdateNotificationSettings(ctx context.Context, userId uuid.UUID, delivery *models.EmailDelivery, prefs []models.NotificationPreference) (*models.NotificationSettings, error) {
+	if delivery != nil && !delivery.Valid() {
+		return nil, ErrEmailDelivery
+	}
+	for _, pref := range prefs {
+		if !pref.Type.Valid() {
+			return nil, ErrNotificationType
+		}
+	}
+
+	if delivery != nil {
+		err := s.store.SetEmailDelivery(ctx, userId, *delivery)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(prefs) > 0 {
+		err := s.store.SetNotificationPreferences(ctx, userId, prefs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.store.GetNotificationSettings(ctx, userId)
+}
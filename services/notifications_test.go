@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/primekobie/hazel/auth"
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// notificationStore records the notifications created through it. Users
+// receive digests with the preferences in prefs, and are workspace members
+// unless they are in removed.
+type notificationStore struct {
+	models.WorkspaceStore
+	prefs   []models.NotificationPreference
+	removed map[uuid.UUID]bool
+	created []models.Notification
+}
+
+func (f *notificationStore) GetMemberRole(ctx context.Context, workspaceId, userId uuid.UUID) (models.Role, error) {
+	if f.removed[userId] {
+		return "", models.ErrNotFound
+	}
+	return models.RoleMember, nil
+}
+
+func (f *notificationStore) GetNotificationSettings(ctx context.Context, userId uuid.UUID) (*models.NotificationSettings, error) {
+	return &models.NotificationSettings{User: models.User{Id: userId}, Delivery: models.DeliveryDigest, Preferences: f.prefs}, nil
+}
+
+func (f *notificationStore) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (f *notificationStore) CreateNotification(ctx context.Context, notification *models.Notification) error {
+	f.created = append(f.created, *notification)
+	return nil
+}
+
+func TestNotify(t *testing.T) {
+	actorId, assigneeId := uuid.New(), uuid.New()
+	ctx := auth.WithUserID(context.Background(), actorId)
+
+	store := &notificationStore{}
+	s := &WorkspaceService{store: store}
+
+	s.notify(ctx, []uuid.UUID{actorId, assigneeId}, models.Notification{Type: models.NotificationTaskAssigned, Subject: "Fix login"})
+
+	require.Len(t, store.created, 1)
+	n := store.created[0]
+	assert.Equal(t, assigneeId, n.UserId)
+	assert.Equal(t, actorId, n.Actor.Id)
+	assert.NotEqual(t, uuid.Nil, n.Id)
+	assert.False(t, n.CreatedAt.IsZero())
+	assert.True(t, n.InApp)
+	assert.True(t, n.EmailPending)
+}
+
+func TestNotify_SkipsNonMembers(t *testing.T) {
+	memberId, removedId := uuid.New(), uuid.New()
+
+	store := &notificationStore{removed: map[uuid.UUID]bool{removedId: true}}
+	s := &WorkspaceService{store: store}
+
+	s.notify(context.Background(), []uuid.UUID{removedId, memberId}, models.Notification{Type: models.NotificationTaskUpdated, WorkspaceId: uuid.New()})
+
+	require.Len(t, store.created, 1)
+	assert.Equal(t, memberId, store.created[0].UserId)
+}
+
+func TestNotify_Preferences(t *testing.T) {
+	tests := []struct {
+		name      string
+		pref      models.NotificationPreference
+		created   bool
+		inApp     bool
+		emailPending bool
+	}{
+		{name: "default", pref: models.NotificationTaskUpdated.DefaultPreference(), created: true, inApp: true},
+		{name: "email only", pref: models.NotificationPreference{Type: models.NotificationTaskUpdated, Email: true}, created: true, emailPending: true},
+		{name: "off", pref: models.NotificationPreference{Type: models.NotificationTaskUpdated}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &notificationStore{prefs: []models.NotificationPreference{tt.pref}}
+			s := &WorkspaceService{store: store}
+
+			s.notify(context.Background(), []uuid.UUID{uuid.New()}, models.Notification{Type: models.NotificationTaskUpdated})
+
+			if !tt.created {
+				assert.Empty(t, store.created)
+				return
+			}
+			require.Len(t, store.created, 1)
+			assert.Equal(t, tt.inApp, store.created[0].InApp)
+			assert.Equal(t, tt.emailPending, store.created[0].EmailPending)
+		})
+	}
+}
+
+func TestUpdateNotificationSettings_Invalid(t *testing.T) {
+	s := &WorkspaceService{}
+
+	_, err := s.UpdateNotificationSettings(context.Background(), uuid.New(), nil, []models.NotificationPreference{{Type: "task_deleted"}})
+	assert.ErrorIs(t, err, ErrNotificationType)
+
+	delivery := models.EmailDelivery("weekly")
+	_, err = s.UpdateNotificationSettings(context.Background(), uuid.New(), &delivery, nil)
+	assert.ErrorIs(t, err, ErrEmailDelivery)
+}
+
+func TestBuildDigest(t *testing.T) {
+	user := &models.User{Name: "Ada", Email: "ada@example.com"}
+	due := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+	notifications := []models.Notification{
+		{Type: models.NotificationTaskAssigned, Actor: &models.User{Name: "Grace"}, Subject: "Fix login"},
+		{Type: models.NotificationTaskStatus, Subject: "Fix login", Changes: map[string]models.Change{"status": {Before: "todo", After: "done"}}},
+		{Type: models.NotificationTaskDueSoon, Subject: "Ship release", Changes: map[string]models.Change{"due": {After: due.Format(time.RFC3339)}}},
+	}
+
+	data := buildDigest(user, notifications)
+
+	assert.Equal(t, 3, data.Count)
+	assert.Equal(t, "ada@example.com", data.Address.Email)
+	require.Len(t, data.Sections, 3)
+	assert.Equal(t, "Due soon", data.Sections[0].Title)
+	assert.Equal(t, []string{"“Ship release” is due March 14 at 09:30 UTC"}, data.Sections[0].Entries)
+	assert.Equal(t, []string{"Grace assigned you to “Fix login”"}, data.Sections[1].Entries)
+	assert.Equal(t, []string{"Someone moved “Fix login” from todo to done"}, data.Sections[2].Entries)
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/primekobie/hazel/mail"
+	"github.com/primekobie/hazel/models"
+)
+
+const (
+	// MaxMailAttempts is how many times delivering an email is attempted
+	// before it is moved to the dead letters.
+	MaxMailAttempts = 8
+
+	mailBatchSize  = 20
+	mailLease      = 2 * time.Minute
+	mailRetryDelay = 30 * time.Second
+	mailMaxDelay   = 2 * time.Hour
+)
+
+// mailBackoff returns how long to wait before the next attempt after the
+// given number of failed attempts, doubling with every attempt.
+func mailBackoff(attempts int) time.Duration {
+	delay := mailRetryDelay
+	for i := 1; i < attempts && delay < mailMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, mailMaxDelay)
+}
+
+// MailWorker delivers the emails queued in the outbox, retrying failed
+// deliveries with exponential backoff.
+type MailWorker struct {
+	outbox   models.OutboxStore
+	mailer   *mail.Mailer
+	interval time.Duration
+}
+
+func NewMailWorker(outbox models.OutboxStore, mailer *mail.Mailer, interval time.Duration) *MailWorker {
+	return &MailWorker{
+		outbox:   outbox,
+		mailer:   mailer,
+		interval: interval,
+	}
+}
+
+// Run delivers due emails every interval until ctx is done. An email being
+// delivered when ctx is done is finished first.
+func (w *MailWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.deliverDue(ctx, context.WithoutCancel(ctx))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Drain delivers the emails that are due until there are none left or ctx
+// is done. It is used on shutdown to flush the outbox.
+func (w *MailWorker) Drain(ctx context.Context) {
+	w.deliverDue(ctx, ctx)
+}
+
+// deliverDue claims and delivers batches of due emails until none are left
+// or stop is done. Deliveries use ctx, so they can outlive stop.
+func (w *MailWorker) deliverDue(stop, ctx context.Context) {
+	for stop.Err() == nil {
+		mails, err := w.outbox.ClaimMail(ctx, mailBatchSize, mailLease)
+		if err != nil {
+			slog.Error("failed to claim outbox mail", "error", err)
+			return
+		}
+
+		for i := range mails {
+			if stop.Err() != nil {
+				// unclaimed emails are picked up again once their lease ends
+				return
+			}
+			w.deliver(ctx, &mails[i])
+		}
+
+		if len(mails) < mailBatchSize {
+			return
+		}
+	}
+}
+
+// deliver attempts to send one email and records the outcome.
+func (w *MailWorker) deliver(ctx context.Context, outboxMail *models.OutboxMail) {
+	var msg mail.Message
+	err := json.Unmarshal(outboxMail.Message, &msg)
+	if err == nil {
+		err = w.mailer.Deliver(ctx, &msg)
+	}
+
+	if err == nil {
+		if err := w.outbox.MarkMailSent(ctx, outboxMail.Id, time.Now().UTC()); err != nil {
+			slog.Error("failed to mark outbox mail sent", "error", err, "mail_id", outboxMail.Id)
+		}
+		return
+	}
+
+	attempts := outboxMail.Attempts + 1
+	if attempts >= MaxMailAttempts {
+		slog.Error("giving up on outbox mail", "error", err, "mail_id", outboxMail.Id, "attempts", attempts)
+		err = w.outbox.BuryMail(ctx, outboxMail.Id, attempts, err.Error())
+	} else {
+		next := time.Now().UTC().Add(mailBackoff(attempts))
+		slog.Warn("failed to deliver outbox mail", "error", err, "mail_id", outboxMail.Id, "attempts", attempts, "retry_at", next)
+		err = w.outbox.RetryMail(ctx, outboxMail.Id, attempts, next, err.Error())
+	}
+	if err != nil {
+		slog.Error("failed to record outbox mail attempt", "error", err, "mail_id", outboxMail.Id)
+	}
+}
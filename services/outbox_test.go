@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/primekobie/hazel/mail"
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMailBackoff(t *testing.T) {
+	assert.Equal(t, mailRetryDelay, mailBackoff(1))
+	assert.Equal(t, 2*mailRetryDelay, mailBackoff(2))
+	assert.Equal(t, 8*mailRetryDelay, mailBackoff(4))
+	assert.Equal(t, mailMaxDelay, mailBackoff(MaxMailAttempts*4))
+}
+
+// outboxStore hands out the emails in pending once and records what became
+// of them.
+type outboxStore struct {
+	models.OutboxStore
+	pending  []models.OutboxMail
+	sent     []uuid.UUID
+	retried  map[uuid.UUID]int
+	buried   map[uuid.UUID]int
+	retryAts []time.Time
+}
+
+func (f *outboxStore) ClaimMail(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxMail, error) {
+	claimed := f.pending[:min(limit, len(f.pending))]
+	f.pending = f.pending[len(claimed):]
+	return claimed, nil
+}
+
+func (f *outboxStore) MarkMailSent(ctx context.Context, id uuid.UUID, sentAt time.Time) error {
+	f.sent = append(f.sent, id)
+	return nil
+}
+
+func (f *outboxStore) RetryMail(ctx context.Context, id uuid.UUID, attempts int, nextAttemptAt time.Time, lastError string) error {
+	f.retried[id] = attempts
+	f.retryAts = append(f.retryAts, nextAttemptAt)
+	return nil
+}
+
+func (f *outboxStore) BuryMail(ctx context.Context, id uuid.UUID, attempts int, lastError string) error {
+	f.buried[id] = attempts
+	return nil
+}
+
+// transportFunc delivers messages by calling itself.
+type transportFunc func(ctx context.Context, msg *mail.Message) error
+
+func (f transportFunc) Send(ctx context.Context, msg *mail.Message) error {
+	return f(ctx, msg)
+}
+
+func outboxMail(t *testing.T, attempts int) models.OutboxMail {
+	payload, err := json.Marshal(mail.Message{To: []mail.Address{{Email: "ada@example.com"}}, Subject: "hello"})
+	require.NoError(t, err)
+	return models.OutboxMail{Id: uuid.New(), Message: payload, Status: models.MailPending, Attempts: attempts}
+}
+
+func TestMailWorker_Drain(t *testing.T) {
+	t.Run("delivered", func(t *testing.T) {
+		var subjects []string
+		transport := transportFunc(func(ctx context.Context, msg *mail.Message) error {
+			subjects = append(subjects, msg.Subject)
+			return nil
+		})
+
+		store := &outboxStore{pending: []models.OutboxMail{outboxMail(t, 0), outboxMail(t, 3)}}
+		worker := NewMailWorker(store, mail.NewMailer(&mail.Config{}, transport), time.Second)
+
+		worker.Drain(context.Background())
+
+		assert.Len(t, store.sent, 2)
+		assert.Equal(t, []string{"hello", "hello"}, subjects)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		transport := transportFunc(func(ctx context.Context, msg *mail.Message) error {
+			return errors.New("connection refused")
+		})
+
+		retry, last := outboxMail(t, 0), outboxMail(t, MaxMailAttempts-1)
+		store := &outboxStore{
+			pending: []models.OutboxMail{retry, last},
+			retried: map[uuid.UUID]int{},
+			buried:  map[uuid.UUID]int{},
+		}
+		worker := NewMailWorker(store, mail.NewMailer(&mail.Config{}, transport), time.Second)
+
+		before := time.Now().UTC()
+		worker.Drain(context.Background())
+
+		assert.Empty(t, store.sent)
+		assert.Equal(t, map[uuid.UUID]int{retry.Id: 1}, store.retried)
+		assert.Equal(t, map[uuid.UUID]int{last.Id: MaxMailAttempts}, store.buried)
+		require.Len(t, store.retryAts, 1)
+		assert.WithinDuration(t, before.Add(mailRetryDelay), store.retryAts[0], time.Second)
+	})
+}
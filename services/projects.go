@@ -15,12 +15,15 @@ func (s *WorkspaceService) CreateProject(ctx context.Context, project *models.Pr
 	project.CreatedAt = lastModified
 	project.LastModified = lastModified
 	project.Status = "active"
+	project.Statuses = defaultStatuses(project.Id, lastModified.UTC())
 
 	err := s.store.CreateProject(ctx, project)
 	if err != nil {
 		return err
 	}
 
+	s.record(ctx, project.Workspace.Id, models.ResourceProject, project.Id, models.ActionCreated, diff(nil, projectFields(project)))
+
 	return nil
 }
 
@@ -35,6 +38,7 @@ func (s *WorkspaceService) UpdateProject(ctx context.Context, data map[string]an
 	if err != nil {
 		return nil, err
 	}
+	before := projectFields(project)
 
 	name, ok := data["name"]
 	if ok {
@@ -71,13 +75,29 @@ func (s *WorkspaceService) UpdateProject(ctx context.Context, data map[string]an
 		return nil, err
 	}
 
+	if changes := diff(before, projectFields(project)); len(changes) > 0 {
+		s.record(ctx, project.Workspace.Id, models.ResourceProject, project.Id, models.ActionUpdated, changes)
+	}
+
 	return project, nil
 }
 
 func (s *WorkspaceService) DeleteProject(ctx context.Context, id uuid.UUID) error {
-	return s.store.DeleteProject(ctx, id)
+	project, err := s.store.GetProject(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.store.DeleteProject(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	s.record(ctx, project.Workspace.Id, models.ResourceProject, project.Id, models.ActionDeleted, diff(projectFields(project), nil))
+
+	return nil
 }
 
-func (s *WorkspaceService) GetProjectsForWorkspace(ctx context.Context, workspaceId uuid.UUID) ([]models.Project, error) {
-	return s.store.GetWorkspaceProjects(ctx, workspaceId)
+func (s *WorkspaceService) GetProjectsForWorkspace(ctx context.Context, workspaceId uuid.UUID, filter models.ProjectFilter) (*models.Page[models.Project], error) {
+	return s.store.GetWorkspaceProjects(ctx, workspaceId, filter)
 }
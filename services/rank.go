@@ -0,0 +1,84 @@
+package services
+
+import (
+	"strings"
+)
+
+// rankDigits are the digits of task ranks in ascending byte order, so ranks
+// compare the same way in Go as under the "C" collation of tasks.rank.
+const rankDigits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// maxRankLength is the length past which the ranks of a column are too dense
+// and get spread out again.
+const maxRankLength = 12
+
+// rankDigit returns the value of the i-th digit of rank, treating digits past
+// its end as zero, or -1 for a character that is not a rank digit.
+func rankDigit(rank string, i int) int {
+	if i >= len(rank) {
+		return 0
+	}
+	return strings.IndexByte(rankDigits, rank[i])
+}
+
+// rankBetween returns a rank that sorts after a and before b, reading ranks
+// as fractions in base 62. An empty a or b leaves that side unbounded. ok is
+// false if a does not sort before b or there is no rank between them within
+// maxRankLength digits.
+func rankBetween(a, b string) (rank string, ok bool) {
+	if b != "" && a >= b {
+		return "", false
+	}
+
+	base := len(rankDigits)
+	unbounded := b == ""
+	digits := make([]byte, 0, maxRankLength)
+
+	for i := 0; i < maxRankLength; i++ {
+		lo, hi := rankDigit(a, i), base
+		if !unbounded {
+			hi = rankDigit(b, i)
+		}
+		if lo < 0 || hi < 0 {
+			return "", false
+		}
+
+		if hi-lo > 1 {
+			return string(append(digits, rankDigits[(lo+hi)/2])), true
+		}
+
+		// anything longer than the common prefix and lo already sorts before b
+		digits = append(digits, rankDigits[lo])
+		if hi-lo == 1 {
+			unbounded = true
+		}
+	}
+
+	return "", false
+}
+
+// spreadRanks returns n evenly spaced ranks of the same length in ascending
+// order, leaving room for a digit's worth of ranks between neighbours.
+func spreadRanks(n int) []string {
+	base := len(rankDigits)
+
+	width, span := 1, base
+	for span < (n+1)*base {
+		width++
+		span *= base
+	}
+	step := span / (n + 1)
+
+	ranks := make([]string, n)
+	for i := range ranks {
+		value := (i + 1) * step
+		digits := make([]byte, width)
+		for j := width - 1; j >= 0; j-- {
+			digits[j] = rankDigits[value%base]
+			value /= base
+		}
+		ranks[i] = string(digits)
+	}
+
+	return ranks
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankBetween(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+		ok   bool
+	}{
+		{name: "empty column", a: "", b: "", want: "V", ok: true},
+		{name: "after last", a: "V", b: "", want: "k", ok: true},
+		{name: "before first", a: "", b: "V", want: "F", ok: true},
+		{name: "between", a: "A", b: "C", want: "B", ok: true},
+		{name: "adjacent digits", a: "A", b: "B", want: "AV", ok: true},
+		{name: "prefix", a: "A", b: "A1", want: "A0V", ok: true},
+		{name: "after highest digit", a: "z", b: "", want: "zV", ok: true},
+		{name: "out of order", a: "C", b: "A", ok: false},
+		{name: "equal", a: "A", b: "A", ok: false},
+		{name: "invalid digit", a: "A-", b: "B", ok: false},
+		{name: "too dense", a: "A00000000000", b: "A00000000001", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rank, ok := rankBetween(tt.a, tt.b)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, rank)
+		})
+	}
+}
+
+func TestRankBetween_Repeated(t *testing.T) {
+	// inserting at the same spot keeps the ranks ordered until they get too long
+	lower, upper := "A", "B"
+	for {
+		rank, ok := rankBetween(lower, upper)
+		if !ok {
+			break
+		}
+		assert.Less(t, lower, rank)
+		assert.Less(t, rank, upper)
+		assert.LessOrEqual(t, len(rank), maxRankLength)
+		upper = rank
+	}
+}
+
+func TestSpreadRanks(t *testing.T) {
+	for _, n := range []int{0, 1, 61, 62, 1000} {
+		ranks := spreadRanks(n)
+		assert.Len(t, ranks, n)
+		assert.True(t, slices.IsSorted(ranks))
+		assert.Len(t, slices.Compact(slices.Clone(ranks)), n)
+
+		for i, rank := range ranks {
+			assert.Len(t, rank, len(ranks[0]))
+
+			// every gap has room for new ranks
+			next := ""
+			if i+1 < n {
+				next = ranks[i+1]
+			}
+			_, ok := rankBetween(rank, next)
+			assert.True(t, ok)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+)
+
+// maxSearchLength is the longest search query that is accepted.
+const maxSearchLength = 200
+
+// Search finds the tasks and projects of a workspace matching filter.
+func (s *WorkspaceService) Search(ctx context.Context, workspaceId uuid.UUID, filter models.SearchFilter) (*models.Page[models.SearchResult], error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	if filter.Query == "" || len(filter.Query) > maxSearchLength {
+		return nil, ErrInvalidSearch
+	}
+
+	for _, t := range filter.Types {
+		if t != models.ResourceTask && t != models.ResourceProject {
+			return nil, ErrInvalidSearchType
+		}
+	}
+
+	return s.store.Search(ctx, workspaceId, filter)
+}
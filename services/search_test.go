@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter models.SearchFilter
+		want   error
+	}{
+		{name: "blank query", filter: models.SearchFilter{Query: "  "}, want: ErrInvalidSearch},
+		{name: "long query", filter: models.SearchFilter{Query: strings.Repeat("a", maxSearchLength+1)}, want: ErrInvalidSearch},
+		{name: "unknown type", filter: models.SearchFilter{Query: "login", Types: []models.Resource{models.ResourceComment}}, want: ErrInvalidSearchType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &WorkspaceService{}
+			_, err := s.Search(context.Background(), uuid.New(), tt.filter)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+)
+
+func sprintFields(sprint *models.Sprint) fields {
+	return fields{
+		"name":      sprint.Name,
+		"goal":      sprint.Goal,
+		"startDate": timeField(sprint.StartDate.Time, models.DateLayout),
+		"endDate":   timeField(sprint.EndDate.Time, models.DateLayout),
+		"state":     string(sprint.State),
+	}
+}
+
+// validDateRange reports whether end, if set, is not before start.
+func validDateRange(start, end models.Date) bool {
+	return start.IsZero() || end.IsZero() || !end.Before(start.Time)
+}
+
+func (s *WorkspaceService) CreateSprint(ctx context.Context, projectId uuid.UUID, name, goal string, startDate, endDate models.Date) (*models.Sprint, error) {
+	if !validDateRange(startDate, endDate) {
+		return nil, ErrInvalidDateRange
+	}
+
+	now := time.Now().UTC()
+	sprint := &models.Sprint{
+		Id:           uuid.New(),
+		ProjectId:    projectId,
+		Name:         strings.TrimSpace(name),
+		Goal:         goal,
+		StartDate:    startDate,
+		EndDate:      endDate,
+		State:        models.SprintPlanned,
+		CreatedAt:    now,
+		LastModified: now,
+	}
+
+	err := s.store.CreateSprint(ctx, sprint)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordFor(ctx, models.ResourceProject, projectId, models.ResourceSprint, sprint.Id, models.ActionCreated, diff(nil, sprintFields(sprint)))
+
+	return sprint, nil
+}
+
+func (s *WorkspaceService) GetProjectSprints(ctx context.Context, projectId uuid.UUID) ([]models.Sprint, error) {
+	return s.store.GetProjectSprints(ctx, projectId)
+}
+
+// GetSprint returns a sprint of the project, reporting sprints of other
+// projects as not found.
+func (s *WorkspaceService) GetSprint(ctx context.Context, projectId, sprintId uuid.UUID) (*models.Sprint, error) {
+	sprint, err := s.store.GetSprint(ctx, sprintId)
+	if err != nil {
+		return nil, err
+	} else if sprint.ProjectId != projectId {
+		return nil, models.ErrNotFound
+	}
+
+	return sprint, nil
+}
+
+// UpdateSprint changes the fields of a sprint that are not nil. Closed
+// sprints cannot be changed.
+func (s *WorkspaceService) UpdateSprint(ctx context.Context, projectId, sprintId uuid.UUID, name, goal *string, startDate, endDate *models.Date) (*models.Sprint, error) {
+	sprint, err := s.GetSprint(ctx, projectId, sprintId)
+	if err != nil {
+		return nil, err
+	} else if sprint.State == models.SprintClosed {
+		return nil, ErrSprintState
+	}
+	before := sprintFields(sprint)
+
+	if name != nil {
+		sprint.Name = strings.TrimSpace(*name)
+	}
+	if goal != nil {
+		sprint.Goal = *goal
+	}
+	if startDate != nil {
+		sprint.StartDate = *startDate
+	}
+	if endDate != nil {
+		sprint.EndDate = *endDate
+	}
+
+	if !validDateRange(sprint.StartDate, sprint.EndDate) {
+		return nil, ErrInvalidDateRange
+	}
+	sprint.LastModified = time.Now().UTC()
+
+	err = s.store.UpdateSprint(ctx, sprint)
+	if err != nil {
+		return nil, err
+	}
+
+	if changes := diff(before, sprintFields(sprint)); len(changes) > 0 {
+		s.recordFor(ctx, models.ResourceProject, projectId, models.ResourceSprint, sprint.Id, models.ActionUpdated, changes)
+	}
+
+	return sprint, nil
+}
+
+// DeleteSprint removes a sprint, returning its tasks to the backlog.
+func (s *WorkspaceService) DeleteSprint(ctx context.Context, projectId, sprintId uuid.UUID) error {
+	sprint, err := s.GetSprint(ctx, projectId, sprintId)
+	if err != nil {
+		return err
+	}
+
+	err = s.store.DeleteSprint(ctx, sprint.Id)
+	if err != nil {
+		return err
+	}
+
+	s.recordFor(ctx, models.ResourceProject, projectId, models.ResourceSprint, sprint.Id, models.ActionDeleted, diff(sprintFields(sprint), nil))
+
+	return nil
+}
+
+// StartSprint activates a planned sprint, committing it to the tasks it
+// holds.
+func (s *WorkspaceService) StartSprint(ctx context.Context, projectId, sprintId uuid.UUID) (*models.Sprint, error) {
+	sprint, err := s.GetSprint(ctx, projectId, sprintId)
+	if err != nil {
+		return nil, err
+	} else if sprint.State != models.SprintPlanned {
+		return nil, ErrSprintState
+	}
+	before := sprintFields(sprint)
+
+	sprint.State = models.SprintActive
+	sprint.StartedAt = time.Now().UTC()
+	sprint.LastModified = sprint.StartedAt
+
+	err = s.store.StartSprint(ctx, sprint)
+	if err != nil {
+		if strings.Contains(err.Error(), "SQLSTATE 23505") {
+			return nil, ErrSprintActive
+		}
+		return nil, ErrFailedOperation
+	}
+
+	s.recordFor(ctx, models.ResourceProject, projectId, models.ResourceSprint, sprint.Id, models.ActionUpdated, diff(before, sprintFields(sprint)))
+
+	return sprint, nil
+}
+
+// CloseSprint closes an active sprint. Its tasks that are not done move to
+// the sprint carryOverTo, which must be another open sprint of the project,
+// or to the backlog if carryOverTo is uuid.Nil. It returns the number of
+// tasks carried over.
+func (s *WorkspaceService) CloseSprint(ctx context.Context, projectId, sprintId, carryOverTo uuid.UUID) (*models.Sprint, int, error) {
+	sprint, err := s.GetSprint(ctx, projectId, sprintId)
+	if err != nil {
+		return nil, 0, err
+	} else if sprint.State != models.SprintActive {
+		return nil, 0, ErrSprintState
+	}
+
+	if carryOverTo != uuid.Nil {
+		if carryOverTo == sprint.Id {
+			return nil, 0, ErrInvalidSprint
+		}
+		if err := s.validateSprint(ctx, projectId, carryOverTo); err != nil {
+			return nil, 0, err
+		}
+	}
+	before := sprintFields(sprint)
+
+	sprint.State = models.SprintClosed
+	sprint.ClosedAt = time.Now().UTC()
+	sprint.LastModified = sprint.ClosedAt
+
+	carried, err := s.store.CloseSprint(ctx, sprint, carryOverTo)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			// closed by a concurrent request since it was read
+			return nil, 0, ErrSprintState
+		}
+		return nil, 0, err
+	}
+
+	changes := diff(before, sprintFields(sprint))
+	changes["carriedOver"] = models.Change{After: carried}
+	s.recordFor(ctx, models.ResourceProject, projectId, models.ResourceSprint, sprint.Id, models.ActionUpdated, changes)
+
+	return sprint, carried, nil
+}
+
+func (s *WorkspaceService) GetSprintSummary(ctx context.Context, projectId, sprintId uuid.UUID) (*models.SprintSummary, error) {
+	sprint, err := s.GetSprint(ctx, projectId, sprintId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.GetSprintSummary(ctx, sprint)
+}
+
+// validateSprint checks that tasks of the project can be planned into the
+// sprint sprintId.
+func (s *WorkspaceService) validateSprint(ctx context.Context, projectId, sprintId uuid.UUID) error {
+	sprint, err := s.GetSprint(ctx, projectId, sprintId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return ErrInvalidSprint
+		}
+		return err
+	} else if sprint.State == models.SprintClosed {
+		return ErrInvalidSprint
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// sprintStore serves sprints held in memory. When raced is set, sprints are
+// closed by someone else before CloseSprint gets to them.
+type sprintStore struct {
+	models.WorkspaceStore
+	sprints map[uuid.UUID]models.Sprint
+	raced   bool
+	closed  bool
+}
+
+func (f *sprintStore) GetSprint(ctx context.Context, id uuid.UUID) (*models.Sprint, error) {
+	sprint, ok := f.sprints[id]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return &sprint, nil
+}
+
+func (f *sprintStore) CloseSprint(ctx context.Context, sprint *models.Sprint, carryOverTo uuid.UUID) (int, error) {
+	if f.raced {
+		return 0, models.ErrNotFound
+	}
+	f.closed = true
+	return 2, nil
+}
+
+func (f *sprintStore) GetResourceWorkspace(ctx context.Context, resource models.Resource, id uuid.UUID) (uuid.UUID, error) {
+	return uuid.Nil, models.ErrNotFound
+}
+
+func TestCloseSprint(t *testing.T) {
+	project := uuid.New()
+	active, planned, closed, foreign := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	sprints := map[uuid.UUID]models.Sprint{
+		active:  {Id: active, ProjectId: project, State: models.SprintActive},
+		planned: {Id: planned, ProjectId: project, State: models.SprintPlanned},
+		closed:  {Id: closed, ProjectId: project, State: models.SprintClosed},
+		foreign: {Id: foreign, ProjectId: uuid.New(), State: models.SprintPlanned},
+	}
+
+	tests := []struct {
+		name        string
+		sprint      uuid.UUID
+		carryOverTo uuid.UUID
+		want        error
+	}{
+		{name: "to backlog", sprint: active, want: nil},
+		{name: "to planned sprint", sprint: active, carryOverTo: planned, want: nil},
+		{name: "planned sprint", sprint: planned, want: ErrSprintState},
+		{name: "closed sprint", sprint: closed, want: ErrSprintState},
+		{name: "to itself", sprint: active, carryOverTo: active, want: ErrInvalidSprint},
+		{name: "to closed sprint", sprint: active, carryOverTo: closed, want: ErrInvalidSprint},
+		{name: "to sprint of another project", sprint: active, carryOverTo: foreign, want: ErrInvalidSprint},
+		{name: "unknown sprint", sprint: uuid.New(), want: models.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &sprintStore{sprints: sprints}
+			s := &WorkspaceService{store: store}
+
+			sprint, carried, err := s.CloseSprint(context.Background(), project, tt.sprint, tt.carryOverTo)
+			assert.ErrorIs(t, err, tt.want)
+			assert.Equal(t, tt.want == nil, store.closed)
+			if tt.want == nil {
+				assert.Equal(t, models.SprintClosed, sprint.State)
+				assert.Equal(t, 2, carried)
+			}
+		})
+	}
+}
+
+func TestCloseSprint_Concurrent(t *testing.T) {
+	project, sprintId := uuid.New(), uuid.New()
+	store := &sprintStore{
+		sprints: map[uuid.UUID]models.Sprint{sprintId: {Id: sprintId, ProjectId: project, State: models.SprintActive}},
+		raced:   true,
+	}
+	s := &WorkspaceService{store: store}
+
+	_, _, err := s.CloseSprint(context.Background(), project, sprintId, uuid.Nil)
+	assert.ErrorIs(t, err, ErrSprintState)
+}
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+)
+
+// statusKeyPattern restricts status keys to slugs such as "in-review".
+var statusKeyPattern = regexp.MustCompile(`^[a-z0-9]+([_-][a-z0-9]+)*$`)
+
+func statusFields(status *models.ProjectStatus) fields {
+	return fields{
+		"key":      string(status.Key),
+		"name":     status.Name,
+		"category": string(status.Category),
+		"position": status.Position,
+	}
+}
+
+func transitionsField(transitions []models.StatusTransition) any {
+	if len(transitions) == 0 {
+		return nil
+	}
+
+	formatted := make([]string, len(transitions))
+	for i, t := range transitions {
+		formatted[i] = fmt.Sprintf("%s -> %s", t.From, t.To)
+	}
+	return formatted
+}
+
+// defaultStatuses returns a copy of models.DefaultStatuses for a new project.
+func defaultStatuses(projectId uuid.UUID, now time.Time) []models.ProjectStatus {
+	statuses := slices.Clone(models.DefaultStatuses)
+	for i := range statuses {
+		statuses[i].Id = uuid.New()
+		statuses[i].ProjectId = projectId
+		statuses[i].Position = i
+		statuses[i].CreatedAt = now
+		statuses[i].LastModified = now
+	}
+	return statuses
+}
+
+// initialStatus picks the status new tasks start in: the first open status of
+// the workflow, or its first status if none is open.
+func initialStatus(statuses []models.ProjectStatus) (models.ProjectStatus, bool) {
+	for _, status := range statuses {
+		if status.Category == models.CategoryOpen {
+			return status, true
+		}
+	}
+
+	if len(statuses) == 0 {
+		return models.ProjectStatus{}, false
+	}
+	return statuses[0], true
+}
+
+// findStatus returns the status of the workflow with the given key.
+func findStatus(statuses []models.ProjectStatus, key models.TaskStatus) (models.ProjectStatus, bool) {
+	i := slices.IndexFunc(statuses, func(s models.ProjectStatus) bool { return s.Key == key })
+	if i < 0 {
+		return models.ProjectStatus{}, false
+	}
+	return statuses[i], true
+}
+
+// transitionTo checks that a task of the project may move from its status to
+// the status with key to, returning that status.
+func (s *WorkspaceService) transitionTo(ctx context.Context, projectId uuid.UUID, from, to models.TaskStatus) (models.ProjectStatus, error) {
+	statuses, err := s.store.GetProjectStatuses(ctx, projectId)
+	if err != nil {
+		return models.ProjectStatus{}, err
+	}
+
+	status, ok := findStatus(statuses, to)
+	if !ok {
+		return models.ProjectStatus{}, ErrInvalidStatus
+	}
+
+	if from == to {
+		return status, nil
+	}
+
+	transitions, err := s.store.GetStatusTransitions(ctx, projectId)
+	if err != nil {
+		return models.ProjectStatus{}, err
+	}
+
+	if len(transitions) > 0 && !slices.Contains(transitions, models.StatusTransition{From: from, To: to}) {
+		return models.ProjectStatus{}, ErrTransitionDenied
+	}
+
+	return status, nil
+}
+
+func (s *WorkspaceService) GetProjectStatuses(ctx context.Context, projectId uuid.UUID) ([]models.ProjectStatus, error) {
+	return s.store.GetProjectStatuses(ctx, projectId)
+}
+
+// CreateStatus appends a status to the workflow of a project.
+func (s *WorkspaceService) CreateStatus(ctx context.Context, projectId uuid.UUID, key, name string, category models.StatusCategory) (*models.ProjectStatus, error) {
+	key = strings.ToLower(strings.TrimSpace(key))
+	if !statusKeyPattern.MatchString(key) {
+		return nil, ErrInvalidStatusKey
+	} else if !category.Valid() {
+		return nil, ErrInvalidCategory
+	}
+
+	statuses, err := s.store.GetProjectStatuses(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	position := 0
+	if len(statuses) > 0 {
+		position = statuses[len(statuses)-1].Position + 1
+	}
+
+	now := time.Now().UTC()
+	status := &models.ProjectStatus{
+		Id:           uuid.New(),
+		ProjectId:    projectId,
+		Key:          models.TaskStatus(key),
+		Name:         strings.TrimSpace(name),
+		Category:     category,
+		Position:     position,
+		CreatedAt:    now,
+		LastModified: now,
+	}
+
+	err = s.store.CreateStatus(ctx, status)
+	if err != nil {
+		if strings.Contains(err.Error(), "SQLSTATE 23505") {
+			return nil, ErrDuplicateEntry
+		}
+		return nil, ErrFailedOperation
+	}
+
+	s.recordFor(ctx, models.ResourceProject, projectId, models.ResourceStatus, status.Id, models.ActionCreated, diff(nil, statusFields(status)))
+
+	return status, nil
+}
+
+// getProjectStatus returns a status of the project's workflow, reporting
+// statuses of other projects as not found.
+func (s *WorkspaceService) getProjectStatus(ctx context.Context, projectId, statusId uuid.UUID) (*models.ProjectStatus, error) {
+	status, err := s.store.GetStatus(ctx, statusId)
+	if err != nil {
+		return nil, err
+	} else if status.ProjectId != projectId {
+		return nil, models.ErrNotFound
+	}
+
+	return status, nil
+}
+
+// UpdateStatus changes the fields of a status that are not nil. The key of a
+// status cannot be changed.
+func (s *WorkspaceService) UpdateStatus(ctx context.Context, projectId, statusId uuid.UUID, name *string, category *models.StatusCategory, position *int) (*models.ProjectStatus, error) {
+	status, err := s.getProjectStatus(ctx, projectId, statusId)
+	if err != nil {
+		return nil, err
+	}
+	before := statusFields(status)
+
+	if name != nil {
+		status.Name = strings.TrimSpace(*name)
+	}
+	if category != nil {
+		if !category.Valid() {
+			return nil, ErrInvalidCategory
+		}
+		status.Category = *category
+	}
+	if position != nil {
+		status.Position = *position
+	}
+	status.LastModified = time.Now().UTC()
+
+	err = s.store.UpdateStatus(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if changes := diff(before, statusFields(status)); len(changes) > 0 {
+		s.recordFor(ctx, models.ResourceProject, projectId, models.ResourceStatus, status.Id, models.ActionUpdated, changes)
+	}
+
+	return status, nil
+}
+
+// DeleteStatus removes a status no task is in from the workflow of a
+// project, along with the transitions from and to it.
+func (s *WorkspaceService) DeleteStatus(ctx context.Context, projectId, statusId uuid.UUID) error {
+	status, err := s.getProjectStatus(ctx, projectId, statusId)
+	if err != nil {
+		return err
+	}
+
+	statuses, err := s.store.GetProjectStatuses(ctx, projectId)
+	if err != nil {
+		return err
+	} else if len(statuses) <= 1 {
+		return ErrLastStatus
+	}
+
+	err = s.store.DeleteStatus(ctx, status.Id)
+	if err != nil {
+		if strings.Contains(err.Error(), "SQLSTATE 23503") {
+			return ErrStatusInUse
+		}
+		return ErrFailedOperation
+	}
+
+	s.recordFor(ctx, models.ResourceProject, projectId, models.ResourceStatus, status.Id, models.ActionDeleted, diff(statusFields(status), nil))
+
+	return nil
+}
+
+func (s *WorkspaceService) GetStatusTransitions(ctx context.Context, projectId uuid.UUID) ([]models.StatusTransition, error) {
+	return s.store.GetStatusTransitions(ctx, projectId)
+}
+
+// SetStatusTransitions replaces the allowed transitions of a project's
+// workflow. An empty list lets tasks move between any statuses.
+func (s *WorkspaceService) SetStatusTransitions(ctx context.Context, projectId uuid.UUID, transitions []models.StatusTransition) ([]models.StatusTransition, error) {
+	statuses, err := s.store.GetProjectStatuses(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	unique := []models.StatusTransition{}
+	for _, t := range transitions {
+		_, fromOk := findStatus(statuses, t.From)
+		_, toOk := findStatus(statuses, t.To)
+		if !fromOk || !toOk || t.From == t.To {
+			return nil, ErrInvalidTransition
+		}
+
+		if !slices.Contains(unique, t) {
+			unique = append(unique, t)
+		}
+	}
+
+	before, err := s.store.GetStatusTransitions(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store.SetStatusTransitions(ctx, projectId, unique)
+	if err != nil {
+		return nil, ErrFailedOperation
+	}
+
+	// read back so the result has the same ordering as the store
+	after, err := s.store.GetStatusTransitions(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	if changes := diff(fields{"transitions": transitionsField(before)}, fields{"transitions": transitionsField(after)}); len(changes) > 0 {
+		s.recordFor(ctx, models.ResourceProject, projectId, models.ResourceProject, projectId, models.ActionUpdated, changes)
+	}
+
+	return after, nil
+}
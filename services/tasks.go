@@ -2,9 +2,15 @@ package services
 
 import (
 	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"math"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/primekobie/hazel/auth"
 	"github.com/primekobie/hazel/models"
 	"github.com/google/uuid"
 )
@@ -15,18 +21,71 @@ func (s *WorkspaceService) CreateTask(ctx context.Context, task *models.Task) er
 	lastModified := time.Now()
 	task.CreatedAt = lastModified
 	task.LastModified = lastModified
-	task.Status = "todo"
+	statuses, err := s.store.GetProjectStatuses(ctx, task.Project.Id)
+	if err != nil {
+		return err
+	}
+
+	status, ok := initialStatus(statuses)
+	if !ok {
+		return models.ErrNotFound
+	}
+	task.Status = status.Key
+	task.Category = status.Category
+
+	if task.ParentId != uuid.Nil {
+		if err := s.validateParent(ctx, task, task.ParentId); err != nil {
+			return err
+		}
+	}
+
+	if task.SprintId != uuid.Nil {
+		if err := s.validateSprint(ctx, task.Project.Id, task.SprintId); err != nil {
+			return err
+		}
+	}
+
+	if task.MilestoneId != uuid.Nil {
+		if err := s.validateMilestone(ctx, task.Project.Id, task.MilestoneId); err != nil {
+			return err
+		}
+	}
+
+	// the rank is chosen and the task written with its column locked
+	err = s.store.WithinTx(ctx, func(ctx context.Context) error {
+		task.Rank, err = s.rankInColumn(ctx, task, uuid.Nil, uuid.Nil)
+		if err != nil {
+			return err
+		}
 
-	err := s.store.CreateTask(ctx, task)
+		return s.store.CreateTask(ctx, task)
+	})
 	if err != nil {
 		return err
 	}
 
+	s.recordFor(ctx, models.ResourceProject, task.Project.Id, models.ResourceTask, task.Id, models.ActionCreated, diff(nil, taskFields(task)))
+
 	return nil
 }
 
 func (s *WorkspaceService) GetTask(ctx context.Context, id uuid.UUID) (*models.Task, error) {
-	return s.store.GetTask(ctx, id)
+	task, err := s.store.GetTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	task.Checklist, err = s.store.GetChecklist(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []models.Task{*task}
+	if err := s.loadLabels(ctx, tasks); err != nil {
+		return nil, err
+	}
+
+	return &tasks[0], nil
 }
 
 func (s *WorkspaceService) UpdateTask(ctx context.Context, data map[string]any) (*models.Task, error) {
@@ -36,6 +95,7 @@ func (s *WorkspaceService) UpdateTask(ctx context.Context, data map[string]any)
 	if err != nil {
 		return nil, err
 	}
+	before := taskFields(task)
 
 	title, ok := data["title"]
 	if ok {
@@ -47,9 +107,12 @@ func (s *WorkspaceService) UpdateTask(ctx context.Context, data map[string]any)
 		task.Description = description.(string)
 	}
 
-	status, ok := data["status"]
-	if ok {
-		task.Status = models.TaskStatus(status.(string))
+	// a new status is applied when the task is written, with the column it
+	// moves to locked
+	status, statusChanged := data["status"]
+	key, ok := status.(string)
+	if statusChanged && !ok {
+		return nil, ErrInvalidStatus
 	}
 
 	priority, ok := data["priority"]
@@ -62,26 +125,349 @@ func (s *WorkspaceService) UpdateTask(ctx context.Context, data map[string]any)
 		task.Due = due.(time.Time)
 	}
 
+	estimate, ok := data["estimate"]
+	if ok {
+		seconds, ok := estimate.(float64)
+		if !ok || seconds < 0 || seconds != math.Trunc(seconds) {
+			return nil, ErrInvalidEstimate
+		}
+		task.Estimate = int64(seconds)
+	}
+
+	parent, ok := data["parentId"]
+	if ok {
+		parentId, err := parseNullableId(parent, ErrInvalidParent)
+		if err != nil {
+			return nil, err
+		}
+
+		if parentId != uuid.Nil && parentId != task.ParentId {
+			if err := s.validateParent(ctx, task, parentId); err != nil {
+				return nil, err
+			}
+		}
+		task.ParentId = parentId
+	}
+
+	sprint, ok := data["sprintId"]
+	if ok {
+		sprintId, err := parseNullableId(sprint, ErrInvalidSprint)
+		if err != nil {
+			return nil, err
+		}
+
+		if sprintId != uuid.Nil && sprintId != task.SprintId {
+			if err := s.validateSprint(ctx, task.Project.Id, sprintId); err != nil {
+				return nil, err
+			}
+		}
+		task.SprintId = sprintId
+	}
+
+	milestone, ok := data["milestoneId"]
+	if ok {
+		milestoneId, err := parseNullableId(milestone, ErrInvalidMilestone)
+		if err != nil {
+			return nil, err
+		}
+
+		if milestoneId != uuid.Nil && milestoneId != task.MilestoneId {
+			if err := s.validateMilestone(ctx, task.Project.Id, milestoneId); err != nil {
+				return nil, err
+			}
+		}
+		task.MilestoneId = milestoneId
+	}
+
 	task.LastModified = time.Now()
 
-	err = s.store.UpdateTask(ctx, task)
+	force, _ := data["force"].(bool)
+	err = s.store.WithinTx(ctx, func(ctx context.Context) error {
+		if statusChanged {
+			if err := s.changeStatus(ctx, task, models.TaskStatus(key), force); err != nil {
+				return err
+			}
+		}
+
+		return s.store.UpdateTask(ctx, task)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if changes := diff(before, taskFields(task)); len(changes) > 0 {
+		s.recordFor(ctx, models.ResourceProject, task.Project.Id, models.ResourceTask, task.Id, models.ActionUpdated, changes)
+		s.notifyAssignees(ctx, task, changes)
+	}
+
+	return task, nil
+}
+
+// changeStatus moves task to the status with the given key if the project's
+// workflow allows it, placing it at the end of its new status column.
+func (s *WorkspaceService) changeStatus(ctx context.Context, task *models.Task, key models.TaskStatus, force bool) error {
+	target, err := s.transitionTo(ctx, task.Project.Id, task.Status, key)
+	if err != nil {
+		return err
+	}
+
+	// completing a blocked task has to be asked for explicitly
+	if target.Category == models.CategoryDone && task.Category != models.CategoryDone && task.Blocked && !force {
+		return ErrTaskBlocked
+	}
+
+	if target.Key == task.Status {
+		return nil
+	}
+
+	task.Status = target.Key
+	task.Category = target.Category
+
+	task.Rank, err = s.rankInColumn(ctx, task, uuid.Nil, uuid.Nil)
+	return err
+}
+
+// MoveTask places a task in its status column, or in the column of status if
+// it is not empty, directly after the task afterId or before the task
+// beforeId. Without either the task goes to the end of the column; with both
+// they have to be neighbours.
+func (s *WorkspaceService) MoveTask(ctx context.Context, id uuid.UUID, status models.TaskStatus, afterId, beforeId uuid.UUID, force bool) (*models.Task, error) {
+	task, err := s.store.GetTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := taskFields(task)
+	before["rank"] = task.Rank
+
+	// the column is read, spread out if needed and the task written within
+	// one transaction that holds the column's lock, so concurrent moves into
+	// it cannot take the same rank
+	err = s.store.WithinTx(ctx, func(ctx context.Context) error {
+		if status != "" {
+			if err := s.changeStatus(ctx, task, status, force); err != nil {
+				return err
+			}
+		}
+
+		task.Rank, err = s.rankInColumn(ctx, task, afterId, beforeId)
+		if err != nil {
+			return err
+		}
+		task.LastModified = time.Now()
+
+		// status and rank are written by a single statement
+		return s.store.UpdateTask(ctx, task)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	after := taskFields(task)
+	after["rank"] = task.Rank
+	if changes := diff(before, after); len(changes) > 0 {
+		s.recordFor(ctx, models.ResourceProject, task.Project.Id, models.ResourceTask, task.Id, models.ActionUpdated, changes)
+
+		// assignees are told about status changes, not about reordering
+		notified := maps.Clone(changes)
+		delete(notified, "rank")
+		if len(notified) > 0 {
+			s.notifyAssignees(ctx, task, notified)
+		}
+	}
+
 	return task, nil
 }
 
+// rankInColumn returns a rank placing task in the column of its status as
+// MoveTask describes. When the neighbouring ranks are too close together the
+// ranks of the column are spread out first. It has to run within the
+// transaction that writes the task.
+func (s *WorkspaceService) rankInColumn(ctx context.Context, task *models.Task, afterId, beforeId uuid.UUID) (string, error) {
+	column, err := s.store.GetColumnRanks(ctx, task.Project.Id, task.Status)
+	if err != nil {
+		return "", err
+	}
+	column = slices.DeleteFunc(column, func(r models.TaskRank) bool { return r.Id == task.Id })
+
+	lower, upper, err := columnNeighbours(column, afterId, beforeId)
+	if err != nil {
+		return "", err
+	}
+
+	rank, ok := rankBetween(lower, upper)
+	if ok {
+		return rank, nil
+	}
+
+	for i, spread := range spreadRanks(len(column)) {
+		column[i].Rank = spread
+	}
+
+	err = s.store.SetTaskRanks(ctx, column)
+	if err != nil {
+		return "", err
+	}
+
+	lower, upper, _ = columnNeighbours(column, afterId, beforeId)
+	rank, ok = rankBetween(lower, upper)
+	if !ok {
+		return "", ErrFailedOperation
+	}
+
+	return rank, nil
+}
+
+// columnNeighbours returns the ranks a task placed after afterId or before
+// beforeId in column has to go between.
+func columnNeighbours(column []models.TaskRank, afterId, beforeId uuid.UUID) (lower, upper string, err error) {
+	index := func(id uuid.UUID) int {
+		return slices.IndexFunc(column, func(r models.TaskRank) bool { return r.Id == id })
+	}
+
+	// insertion point: the position in column the task takes
+	at := len(column)
+	if afterId != uuid.Nil {
+		i := index(afterId)
+		if i < 0 {
+			return "", "", ErrInvalidPosition
+		}
+		at = i + 1
+	}
+
+	if beforeId != uuid.Nil {
+		i := index(beforeId)
+		if i < 0 || (afterId != uuid.Nil && i != at) {
+			return "", "", ErrInvalidPosition
+		}
+		at = i
+	}
+
+	if at > 0 {
+		lower = column[at-1].Rank
+	}
+	if at < len(column) {
+		upper = column[at].Rank
+	}
+
+	return lower, upper, nil
+}
+
 func (s *WorkspaceService) DeleteTask(ctx context.Context, id uuid.UUID) error {
-	return s.store.DeleteTask(ctx, id)
+	task, err := s.store.GetTask(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.store.DeleteTask(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	s.recordFor(ctx, models.ResourceProject, task.Project.Id, models.ResourceTask, task.Id, models.ActionDeleted, diff(taskFields(task), nil))
+
+	return nil
 }
 
-func (s *WorkspaceService) GetProjectTasks(ctx context.Context, projectId uuid.UUID) ([]models.Task, error) {
-	return s.store.GetTasksForProject(ctx, projectId)
+// parseNullableId reads an id such as parentId from an update, where null
+// clears it. Values that are not ids are reported as invalid.
+func parseNullableId(value any, invalid error) (uuid.UUID, error) {
+	if value == nil {
+		return uuid.Nil, nil
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return uuid.Nil, invalid
+	}
+
+	id, err := uuid.Parse(str)
+	if err != nil {
+		return uuid.Nil, invalid
+	}
+
+	return id, nil
+}
+
+// validateParent checks that task can be nested under the task parentId
+// without leaving its project, creating a cycle or exceeding
+// models.MaxTaskDepth.
+func (s *WorkspaceService) validateParent(ctx context.Context, task *models.Task, parentId uuid.UUID) error {
+	parent, err := s.store.GetTask(ctx, parentId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return ErrInvalidParent
+		}
+		return err
+	} else if parent.Project.Id != task.Project.Id {
+		return ErrInvalidParent
+	}
+
+	ancestors, err := s.store.GetTaskAncestors(ctx, parentId)
+	if err != nil {
+		return err
+	} else if slices.Contains(ancestors, task.Id) {
+		return ErrTaskCycle
+	}
+
+	depth, err := s.store.GetSubtaskDepth(ctx, task.Id)
+	if err != nil {
+		return err
+	}
+
+	if len(ancestors)+1+depth > models.MaxTaskDepth {
+		return ErrTaskTooDeep
+	}
+
+	return nil
+}
+
+func (s *WorkspaceService) GetSubtasks(ctx context.Context, taskId uuid.UUID) ([]models.Task, error) {
+	tasks, err := s.store.GetSubtasks(ctx, taskId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.loadLabels(ctx, tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (s *WorkspaceService) GetProjectTasks(ctx context.Context, projectId uuid.UUID, filter models.TaskFilter) (*models.Page[models.Task], error) {
+	if userId, ok := auth.UserIDFromContext(ctx); ok {
+		filter = forUser(filter, userId)
+	}
+
+	page, err := s.store.GetTasksForProject(ctx, projectId, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.loadLabels(ctx, page.Items); err != nil {
+		return nil, err
+	}
+
+	return page, nil
 }
 
 func (s *WorkspaceService) AssignTaskToUser(ctx context.Context, taskId, userId uuid.UUID) error {
-	err := s.store.AssignTask(ctx, taskId, userId)
+	workspaceId, err := s.store.GetResourceWorkspace(ctx, models.ResourceTask, taskId)
+	if err != nil {
+		return err
+	}
+
+	// tasks can only be assigned to members, who are the only ones that may
+	// be told about them
+	_, err = s.store.GetMemberRole(ctx, workspaceId, userId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return ErrInvalidAssignee
+		}
+		return ErrFailedOperation
+	}
+
+	err = s.store.AssignTask(ctx, taskId, userId)
 	if err != nil {
 		if strings.Contains(err.Error(), "SQLSTATE 23505") {
 			return ErrDuplicateEntry
@@ -90,12 +476,29 @@ func (s *WorkspaceService) AssignTaskToUser(ctx context.Context, taskId, userId
 		return ErrFailedOperation
 	}
 
+	s.recordFor(ctx, models.ResourceTask, taskId, models.ResourceTask, taskId, models.ActionAssigned, diff(nil, fields{"assignee": userId}))
+
+	task, err := s.store.GetTask(ctx, taskId)
+	if err != nil {
+		slog.Error("failed to get task for notification", "error", err, "task_id", taskId)
+		return nil
+	}
+	s.notifyTask(ctx, task, models.NotificationTaskAssigned, []uuid.UUID{userId}, nil)
+
 	return nil
 }
 
 func (s *WorkspaceService) GetAssignedUsers(ctx context.Context, taskId uuid.UUID) ([]models.User, error) {
 	return s.store.GetAssignedUsers(ctx, taskId)
 }
+
 func (s *WorkspaceService) UnassignTask(ctx context.Context, taskId, userId uuid.UUID) error {
-	return s.store.UnassignTask(ctx, taskId, userId)
+	err := s.store.UnassignTask(ctx, taskId, userId)
+	if err != nil {
+		return err
+	}
+
+	s.recordFor(ctx, models.ResourceTask, taskId, models.ResourceTask, taskId, models.ActionUnassigned, diff(fields{"assignee": userId}, nil))
+
+	return nil
 }
@@ -0,0 +1,376 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// taskTreeStore serves a task hierarchy held in memory.
+type taskTreeStore struct {
+	models.WorkspaceStore
+	projects map[uuid.UUID]uuid.UUID
+	parents  map[uuid.UUID]uuid.UUID
+}
+
+func (f *taskTreeStore) GetTask(ctx context.Context, id uuid.UUID) (*models.Task, error) {
+	project, ok := f.projects[id]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return &models.Task{Id: id, Project: &models.Project{Id: project}, ParentId: f.parents[id]}, nil
+}
+
+func (f *taskTreeStore) GetTaskAncestors(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	ids := []uuid.UUID{}
+	for ; id != uuid.Nil; id = f.parents[id] {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (f *taskTreeStore) GetSubtaskDepth(ctx context.Context, id uuid.UUID) (int, error) {
+	depth := 0
+	for child, parent := range f.parents {
+		if parent == id {
+			if d, _ := f.GetSubtaskDepth(ctx, child); d+1 > depth {
+				depth = d + 1
+			}
+		}
+	}
+	return depth, nil
+}
+
+func TestValidateParent(t *testing.T) {
+	project, otherProject := uuid.New(), uuid.New()
+	root, child, grandchild, leaf, other := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	store := &taskTreeStore{
+		projects: map[uuid.UUID]uuid.UUID{root: project, child: project, grandchild: project, leaf: project, other: otherProject},
+		parents:  map[uuid.UUID]uuid.UUID{child: root, grandchild: child},
+	}
+	s := &WorkspaceService{store: store}
+
+	tests := []struct {
+		name   string
+		task   uuid.UUID
+		parent uuid.UUID
+		want   error
+	}{
+		{name: "new subtask", task: uuid.New(), parent: child, want: nil},
+		{name: "move leaf under root", task: leaf, parent: root, want: nil},
+		{name: "unknown parent", task: leaf, parent: uuid.New(), want: ErrInvalidParent},
+		{name: "parent in another project", task: leaf, parent: other, want: ErrInvalidParent},
+		{name: "own subtask as parent", task: root, parent: grandchild, want: ErrTaskCycle},
+		{name: "itself as parent", task: child, parent: child, want: ErrTaskCycle},
+		{name: "below the deepest level", task: leaf, parent: grandchild, want: ErrTaskTooDeep},
+		{name: "subtree too deep", task: root, parent: leaf, want: ErrTaskTooDeep},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &models.Task{Id: tt.task, Project: &models.Project{Id: project}}
+			assert.ErrorIs(t, s.validateParent(context.Background(), task, tt.parent), tt.want)
+		})
+	}
+}
+
+// blockedTaskStore serves a single task of a project with the default
+// workflow.
+type blockedTaskStore struct {
+	models.WorkspaceStore
+	task        models.Task
+	transitions []models.StatusTransition
+	updated     bool
+}
+
+func (f *blockedTaskStore) GetTask(ctx context.Context, id uuid.UUID) (*models.Task, error) {
+	task := f.task
+	return &task, nil
+}
+
+func (f *blockedTaskStore) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (f *blockedTaskStore) UpdateTask(ctx context.Context, task *models.Task) error {
+	f.updated = true
+	return nil
+}
+
+func (f *blockedTaskStore) GetProjectStatuses(ctx context.Context, projectId uuid.UUID) ([]models.ProjectStatus, error) {
+	return defaultStatuses(projectId, time.Now()), nil
+}
+
+func (f *blockedTaskStore) GetStatusTransitions(ctx context.Context, projectId uuid.UUID) ([]models.StatusTransition, error) {
+	return f.transitions, nil
+}
+
+func (f *blockedTaskStore) GetColumnRanks(ctx context.Context, projectId uuid.UUID, status models.TaskStatus) ([]models.TaskRank, error) {
+	return []models.TaskRank{}, nil
+}
+
+func (f *blockedTaskStore) GetAssignedUsers(ctx context.Context, taskId uuid.UUID) ([]models.User, error) {
+	return []models.User{}, nil
+}
+
+func (f *blockedTaskStore) GetResourceWorkspace(ctx context.Context, resource models.Resource, id uuid.UUID) (uuid.UUID, error) {
+	return uuid.Nil, models.ErrNotFound
+}
+
+func TestUpdateTask_Blocked(t *testing.T) {
+	task := models.Task{Id: uuid.New(), Project: &models.Project{Id: uuid.New()}, Status: models.StatusInProgress, Category: models.CategoryInProgress, Blocked: true}
+
+	t.Run("rejected", func(t *testing.T) {
+		store := &blockedTaskStore{task: task}
+		s := &WorkspaceService{store: store}
+
+		_, err := s.UpdateTask(context.Background(), map[string]any{"id": task.Id, "status": string(models.StatusDone)})
+		assert.ErrorIs(t, err, ErrTaskBlocked)
+		assert.False(t, store.updated)
+	})
+
+	t.Run("forced", func(t *testing.T) {
+		store := &blockedTaskStore{task: task}
+		s := &WorkspaceService{store: store}
+
+		updated, err := s.UpdateTask(context.Background(), map[string]any{"id": task.Id, "status": string(models.StatusDone), "force": true})
+		assert.NoError(t, err)
+		assert.True(t, store.updated)
+		assert.Equal(t, models.StatusDone, updated.Status)
+	})
+}
+
+func TestUpdateTask_Transitions(t *testing.T) {
+	task := models.Task{Id: uuid.New(), Project: &models.Project{Id: uuid.New()}, Status: models.StatusTodo, Category: models.CategoryOpen}
+	transitions := []models.StatusTransition{
+		{From: models.StatusTodo, To: models.StatusInProgress},
+		{From: models.StatusInProgress, To: models.StatusDone},
+	}
+
+	tests := []struct {
+		name        string
+		transitions []models.StatusTransition
+		status      string
+		want        error
+	}{
+		{name: "unrestricted workflow", transitions: nil, status: string(models.StatusDone), want: nil},
+		{name: "allowed transition", transitions: transitions, status: string(models.StatusInProgress), want: nil},
+		{name: "denied transition", transitions: transitions, status: string(models.StatusDone), want: ErrTransitionDenied},
+		{name: "unchanged status", transitions: transitions, status: string(models.StatusTodo), want: nil},
+		{name: "unknown status", transitions: nil, status: "review", want: ErrInvalidStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &blockedTaskStore{task: task, transitions: tt.transitions}
+			s := &WorkspaceService{store: store}
+
+			updated, err := s.UpdateTask(context.Background(), map[string]any{"id": task.Id, "status": tt.status})
+			assert.ErrorIs(t, err, tt.want)
+			assert.Equal(t, tt.want == nil, store.updated)
+			if tt.want == nil {
+				assert.Equal(t, models.TaskStatus(tt.status), updated.Status)
+			}
+		})
+	}
+}
+
+// columnStore is a blockedTaskStore whose task's column holds column. It
+// records the store calls made outside of a transaction.
+type columnStore struct {
+	blockedTaskStore
+	column    []models.TaskRank
+	spread    []models.TaskRank
+	outsideTx []string
+}
+
+type inTx struct{}
+
+func (f *columnStore) inTx(ctx context.Context, call string) {
+	if ctx.Value(inTx{}) == nil {
+		f.outsideTx = append(f.outsideTx, call)
+	}
+}
+
+func (f *columnStore) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, inTx{}, true))
+}
+
+func (f *columnStore) GetColumnRanks(ctx context.Context, projectId uuid.UUID, status models.TaskStatus) ([]models.TaskRank, error) {
+	f.inTx(ctx, "GetColumnRanks")
+	return slices.Clone(f.column), nil
+}
+
+func (f *columnStore) SetTaskRanks(ctx context.Context, ranks []models.TaskRank) error {
+	f.inTx(ctx, "SetTaskRanks")
+	f.spread = ranks
+	return nil
+}
+
+func (f *columnStore) UpdateTask(ctx context.Context, task *models.Task) error {
+	f.inTx(ctx, "UpdateTask")
+	return f.blockedTaskStore.UpdateTask(ctx, task)
+}
+
+func TestMoveTask_WithinTx(t *testing.T) {
+	task := models.Task{Id: uuid.New(), Project: &models.Project{Id: uuid.New()}, Status: models.StatusTodo, Category: models.CategoryOpen}
+	a, b := uuid.New(), uuid.New()
+
+	// equal ranks leave no room between a and b, so the column is spread out
+	store := &columnStore{
+		blockedTaskStore: blockedTaskStore{task: task},
+		column:           []models.TaskRank{{Id: a, Rank: "V"}, {Id: b, Rank: "V"}},
+	}
+	s := &WorkspaceService{store: store}
+
+	moved, err := s.MoveTask(context.Background(), task.Id, models.StatusInProgress, a, b, false)
+	require.NoError(t, err)
+	assert.Empty(t, store.outsideTx)
+	assert.True(t, store.updated)
+
+	require.Len(t, store.spread, 2)
+	assert.Less(t, store.spread[0].Rank, moved.Rank)
+	assert.Less(t, moved.Rank, store.spread[1].Rank)
+}
+
+// movedTaskStore is a blockedTaskStore whose task is assigned to assignee,
+// recording the notifications sent about it.
+type movedTaskStore struct {
+	blockedTaskStore
+	assignee      uuid.UUID
+	notifications notificationStore
+}
+
+func (f *movedTaskStore) GetAssignedUsers(ctx context.Context, taskId uuid.UUID) ([]models.User, error) {
+	return []models.User{{Id: f.assignee}}, nil
+}
+
+func (f *movedTaskStore) GetResourceWorkspace(ctx context.Context, resource models.Resource, id uuid.UUID) (uuid.UUID, error) {
+	return uuid.Nil, nil
+}
+
+func (f *movedTaskStore) InsertActivity(ctx context.Context, activity *models.Activity) error {
+	return nil
+}
+
+func (f *movedTaskStore) GetMemberRole(ctx context.Context, workspaceId, userId uuid.UUID) (models.Role, error) {
+	return f.notifications.GetMemberRole(ctx, workspaceId, userId)
+}
+
+func (f *movedTaskStore) GetNotificationSettings(ctx context.Context, userId uuid.UUID) (*models.NotificationSettings, error) {
+	return f.notifications.GetNotificationSettings(ctx, userId)
+}
+
+func (f *movedTaskStore) CreateNotification(ctx context.Context, notification *models.Notification) error {
+	return f.notifications.CreateNotification(ctx, notification)
+}
+
+func TestMoveTask_Notifies(t *testing.T) {
+	task := models.Task{Id: uuid.New(), Project: &models.Project{Id: uuid.New()}, Status: models.StatusTodo, Category: models.CategoryOpen}
+
+	t.Run("status change", func(t *testing.T) {
+		store := &movedTaskStore{blockedTaskStore: blockedTaskStore{task: task}, assignee: uuid.New()}
+		s := &WorkspaceService{store: store}
+
+		_, err := s.MoveTask(context.Background(), task.Id, models.StatusInProgress, uuid.Nil, uuid.Nil, false)
+		assert.NoError(t, err)
+
+		created := store.notifications.created
+		if assert.Len(t, created, 1) {
+			assert.Equal(t, store.assignee, created[0].UserId)
+			assert.Equal(t, models.NotificationTaskStatus, created[0].Type)
+			assert.NotContains(t, created[0].Changes, "rank")
+		}
+	})
+
+	t.Run("reorder", func(t *testing.T) {
+		store := &movedTaskStore{blockedTaskStore: blockedTaskStore{task: task}, assignee: uuid.New()}
+		s := &WorkspaceService{store: store}
+
+		_, err := s.MoveTask(context.Background(), task.Id, "", uuid.Nil, uuid.Nil, false)
+		assert.NoError(t, err)
+		assert.Empty(t, store.notifications.created)
+	})
+}
+
+// assignmentStore serves tasks of a single workspace whose members are in
+// members, and records the assignments made through it.
+type assignmentStore struct {
+	models.WorkspaceStore
+	members  map[uuid.UUID]bool
+	assigned []uuid.UUID
+}
+
+func (f *assignmentStore) GetResourceWorkspace(ctx context.Context, resource models.Resource, id uuid.UUID) (uuid.UUID, error) {
+	return uuid.Nil, nil
+}
+
+func (f *assignmentStore) GetMemberRole(ctx context.Context, workspaceId, userId uuid.UUID) (models.Role, error) {
+	if !f.members[userId] {
+		return "", models.ErrNotFound
+	}
+	return models.RoleMember, nil
+}
+
+func (f *assignmentStore) AssignTask(ctx context.Context, taskId, userId uuid.UUID) error {
+	f.assigned = append(f.assigned, userId)
+	return nil
+}
+
+func (f *assignmentStore) InsertActivity(ctx context.Context, activity *models.Activity) error {
+	return nil
+}
+
+func (f *assignmentStore) GetTask(ctx context.Context, id uuid.UUID) (*models.Task, error) {
+	return nil, models.ErrNotFound
+}
+
+func TestAssignTaskToUser(t *testing.T) {
+	memberId, outsiderId := uuid.New(), uuid.New()
+	store := &assignmentStore{members: map[uuid.UUID]bool{memberId: true}}
+	s := &WorkspaceService{store: store}
+
+	err := s.AssignTaskToUser(context.Background(), uuid.New(), outsiderId)
+	assert.ErrorIs(t, err, ErrInvalidAssignee)
+	assert.Empty(t, store.assigned)
+
+	err = s.AssignTaskToUser(context.Background(), uuid.New(), memberId)
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{memberId}, store.assigned)
+}
+
+func TestColumnNeighbours(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	column := []models.TaskRank{{Id: a, Rank: "A"}, {Id: b, Rank: "B"}, {Id: c, Rank: "C"}}
+
+	tests := []struct {
+		name          string
+		after, before uuid.UUID
+		lower, upper  string
+		wantErr       error
+	}{
+		{name: "end of column", lower: "C", upper: ""},
+		{name: "after first", after: a, lower: "A", upper: "B"},
+		{name: "after last", after: c, lower: "C", upper: ""},
+		{name: "before first", before: a, lower: "", upper: "A"},
+		{name: "between neighbours", after: a, before: b, lower: "A", upper: "B"},
+		{name: "between non-neighbours", after: a, before: c, wantErr: ErrInvalidPosition},
+		{name: "unknown task", after: uuid.New(), wantErr: ErrInvalidPosition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lower, upper, err := columnNeighbours(column, tt.after, tt.before)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.lower, lower)
+			assert.Equal(t, tt.upper, upper)
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+)
+
+// maxLoggedDuration is the longest time a single manual entry can log.
+const maxLoggedDuration = 24 * time.Hour
+
+func timeEntryFields(entry *models.TimeEntry) fields {
+	return fields{
+		"note":      entry.Note,
+		"startedAt": timeField(entry.StartedAt, time.RFC3339),
+		"endedAt":   timeField(entry.EndedAt, time.RFC3339),
+		"duration":  entry.Duration,
+	}
+}
+
+// StartTimer starts a timer for the user on the task. A user can only run
+// one timer at a time.
+func (s *WorkspaceService) StartTimer(ctx context.Context, taskId, userId uuid.UUID, note string) (*models.TimeEntry, error) {
+	now := time.Now().UTC()
+	entry := &models.TimeEntry{
+		Id:           uuid.New(),
+		TaskId:       taskId,
+		UserId:       userId,
+		Note:         note,
+		StartedAt:    now,
+		CreatedAt:    now,
+		LastModified: now,
+	}
+
+	err := s.store.CreateTimeEntry(ctx, entry)
+	if err != nil {
+		if strings.Contains(err.Error(), "SQLSTATE 23505") {
+			return nil, ErrTimerRunning
+		}
+		return nil, err
+	}
+
+	s.recordFor(ctx, models.ResourceTask, taskId, models.ResourceTimeEntry, entry.Id, models.ActionCreated, diff(nil, timeEntryFields(entry)))
+
+	return entry, nil
+}
+
+// StopTimer stops the timer the user is running on the task and logs the
+// time since it was started.
+func (s *WorkspaceService) StopTimer(ctx context.Context, taskId, userId uuid.UUID) (*models.TimeEntry, error) {
+	entry, err := s.store.GetRunningTimer(ctx, userId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, ErrNoRunningTimer
+		}
+		return nil, err
+	} else if entry.TaskId != taskId {
+		return nil, ErrNoRunningTimer
+	}
+	before := timeEntryFields(entry)
+
+	entry.EndedAt = time.Now().UTC()
+	entry.Duration = int64(entry.EndedAt.Sub(entry.StartedAt) / time.Second)
+	entry.LastModified = entry.EndedAt
+
+	err = s.store.UpdateTimeEntry(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordFor(ctx, models.ResourceTask, taskId, models.ResourceTimeEntry, entry.Id, models.ActionUpdated, diff(before, timeEntryFields(entry)))
+
+	return entry, nil
+}
+
+// GetRunningTimer returns the timer the user has not stopped yet.
+func (s *WorkspaceService) GetRunningTimer(ctx context.Context, userId uuid.UUID) (*models.TimeEntry, error) {
+	return s.store.GetRunningTimer(ctx, userId)
+}
+
+// validDuration reports whether seconds can be logged in a single entry.
+func validDuration(seconds int64) bool {
+	return seconds > 0 && seconds <= int64(maxLoggedDuration/time.Second)
+}
+
+// LogTime records time the user spent on the task without a timer. Entries
+// without a start time are taken to end now.
+func (s *WorkspaceService) LogTime(ctx context.Context, taskId, userId uuid.UUID, startedAt time.Time, duration int64, note string) (*models.TimeEntry, error) {
+	if !validDuration(duration) {
+		return nil, ErrInvalidDuration
+	}
+
+	now := time.Now().UTC()
+	if startedAt.IsZero() {
+		startedAt = now.Add(-time.Duration(duration) * time.Second)
+	}
+
+	entry := &models.TimeEntry{
+		Id:           uuid.New(),
+		TaskId:       taskId,
+		UserId:       userId,
+		Note:         note,
+		StartedAt:    startedAt.UTC(),
+		Duration:     duration,
+		CreatedAt:    now,
+		LastModified: now,
+	}
+	entry.EndedAt = entry.StartedAt.Add(time.Duration(duration) * time.Second)
+
+	err := s.store.CreateTimeEntry(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordFor(ctx, models.ResourceTask, taskId, models.ResourceTimeEntry, entry.Id, models.ActionCreated, diff(nil, timeEntryFields(entry)))
+
+	return entry, nil
+}
+
+func (s *WorkspaceService) GetTaskTimeEntries(ctx context.Context, taskId uuid.UUID) ([]models.TimeEntry, error) {
+	return s.store.GetTaskTimeEntries(ctx, taskId)
+}
+
+// getTimeEntry returns an entry logged on the task that the user may change.
+// Entries can be changed by the user who logged them or by a workspace
+// admin; entries of other tasks are reported as not found.
+func (s *WorkspaceService) getTimeEntry(ctx context.Context, taskId, entryId, userId uuid.UUID, role models.Role) (*models.TimeEntry, error) {
+	entry, err := s.store.GetTimeEntry(ctx, entryId)
+	if err != nil {
+		return nil, err
+	} else if entry.TaskId != taskId {
+		return nil, models.ErrNotFound
+	}
+
+	if entry.UserId != userId && !role.Includes(models.RoleAdmin) {
+		return nil, ErrPermissionDenied
+	}
+
+	return entry, nil
+}
+
+// UpdateTimeEntry changes the fields of a time entry that are not nil. The
+// duration of a running timer cannot be changed.
+func (s *WorkspaceService) UpdateTimeEntry(ctx context.Context, taskId, entryId, userId uuid.UUID, role models.Role, note *string, startedAt *time.Time, duration *int64) (*models.TimeEntry, error) {
+	entry, err := s.getTimeEntry(ctx, taskId, entryId, userId, role)
+	if err != nil {
+		return nil, err
+	}
+	before := timeEntryFields(entry)
+
+	if note != nil {
+		entry.Note = *note
+	}
+	if startedAt != nil {
+		entry.StartedAt = startedAt.UTC()
+	}
+	if duration != nil {
+		if entry.Running() {
+			return nil, ErrEntryRunning
+		} else if !validDuration(*duration) {
+			return nil, ErrInvalidDuration
+		}
+		entry.Duration = *duration
+	}
+	if !entry.Running() {
+		entry.EndedAt = entry.StartedAt.Add(time.Duration(entry.Duration) * time.Second)
+	}
+	entry.LastModified = time.Now().UTC()
+
+	err = s.store.UpdateTimeEntry(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	if changes := diff(before, timeEntryFields(entry)); len(changes) > 0 {
+		s.recordFor(ctx, models.ResourceTask, taskId, models.ResourceTimeEntry, entry.Id, models.ActionUpdated, changes)
+	}
+
+	return entry, nil
+}
+
+func (s *WorkspaceService) DeleteTimeEntry(ctx context.Context, taskId, entryId, userId uuid.UUID, role models.Role) error {
+	entry, err := s.getTimeEntry(ctx, taskId, entryId, userId, role)
+	if err != nil {
+		return err
+	}
+
+	err = s.store.DeleteTimeEntry(ctx, entry.Id)
+	if err != nil {
+		return err
+	}
+
+	s.recordFor(ctx, models.ResourceTask, taskId, models.ResourceTimeEntry, entry.Id, models.ActionDeleted, diff(timeEntryFields(entry), nil))
+
+	return nil
+}
+
+// GetTimeReport sums the time logged in the entries matching filter.
+func (s *WorkspaceService) GetTimeReport(ctx context.Context, filter models.TimeReportFilter) (*models.TimeReport, error) {
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return nil, ErrInvalidDateRange
+	}
+
+	logs, err := s.store.GetTimeLogs(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildTimeReport(filter, logs), nil
+}
+
+// buildTimeReport rolls logs up into totals per project and user, ordered by
+// name, and per day, in date order.
+func buildTimeReport(filter models.TimeReportFilter, logs []models.TimeLog) *models.TimeReport {
+	report := &models.TimeReport{
+		Filter:   filter,
+		Projects: []models.TimeTotal{},
+		Users:    []models.TimeTotal{},
+		Days:     []models.TimeTotal{},
+	}
+
+	add := func(totals []models.TimeTotal, match func(models.TimeTotal) bool, total models.TimeTotal) []models.TimeTotal {
+		if i := slices.IndexFunc(totals, match); i >= 0 {
+			totals[i].Duration += total.Duration
+			return totals
+		}
+		return append(totals, total)
+	}
+
+	for _, log := range logs {
+		report.Total += log.Duration
+		report.Projects = add(report.Projects, func(t models.TimeTotal) bool { return t.Id == log.ProjectId },
+			models.TimeTotal{Id: log.ProjectId, Name: log.ProjectName, Duration: log.Duration})
+		report.Users = add(report.Users, func(t models.TimeTotal) bool { return t.Id == log.UserId },
+			models.TimeTotal{Id: log.UserId, Name: log.UserName, Duration: log.Duration})
+		report.Days = add(report.Days, func(t models.TimeTotal) bool { return t.Date.Equal(log.Day.Time) },
+			models.TimeTotal{Date: log.Day, Duration: log.Duration})
+	}
+
+	byName := func(a, b models.TimeTotal) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Id.String(), b.Id.String())
+	}
+	slices.SortFunc(report.Projects, byName)
+	slices.SortFunc(report.Users, byName)
+	slices.SortFunc(report.Days, func(a, b models.TimeTotal) int { return a.Date.Compare(b.Date.Time) })
+
+	return report
+}
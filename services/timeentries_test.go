@@ -0,0 +1,44 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildTimeReport(t *testing.T) {
+	web, api := uuid.New(), uuid.New()
+	ada, bob := uuid.New(), uuid.New()
+	monday := models.Date{Time: time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)}
+	tuesday := models.Date{Time: monday.AddDate(0, 0, 1)}
+
+	logs := []models.TimeLog{
+		{ProjectId: web, ProjectName: "Web", UserId: bob, UserName: "Bob", Day: monday, Duration: 1800},
+		{ProjectId: api, ProjectName: "API", UserId: ada, UserName: "Ada", Day: monday, Duration: 3600},
+		{ProjectId: web, ProjectName: "Web", UserId: ada, UserName: "Ada", Day: tuesday, Duration: 600},
+	}
+
+	report := buildTimeReport(models.TimeReportFilter{}, logs)
+
+	assert.Equal(t, int64(6000), report.Total)
+	assert.Equal(t, []models.TimeTotal{
+		{Id: api, Name: "API", Duration: 3600},
+		{Id: web, Name: "Web", Duration: 2400},
+	}, report.Projects)
+	assert.Equal(t, []models.TimeTotal{
+		{Id: ada, Name: "Ada", Duration: 4200},
+		{Id: bob, Name: "Bob", Duration: 1800},
+	}, report.Users)
+	assert.Equal(t, []models.TimeTotal{
+		{Date: monday, Duration: 5400},
+		{Date: tuesday, Duration: 600},
+	}, report.Days)
+
+	empty := buildTimeReport(models.TimeReportFilter{}, nil)
+	assert.Zero(t, empty.Total)
+	assert.NotNil(t, empty.Projects)
+	assert.NotNil(t, empty.Days)
+}
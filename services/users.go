@@ -3,12 +3,15 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"time"
 
 	"github.com/primekobie/hazel/auth"
 	"github.com/primekobie/hazel/mail"
 	"github.com/primekobie/hazel/models"
+	"github.com/primekobie/hazel/storage"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -16,17 +19,20 @@ import (
 const (
 	VERIFICATION   = "verification"
 	AUTHENTICATION = "authentication"
+	PASSWORD_RESET = "password_reset"
 )
 
 type UserService struct {
 	store models.UserStore
-	mail  *mail.Mailer
+	mail  *mailQueue
+	files storage.Storage
 }
 
-func NewUserService(us models.UserStore, m *mail.Mailer) *UserService {
+func NewUserService(us models.UserStore, m *mail.Mailer, outbox models.OutboxStore, files storage.Storage) *UserService {
 	return &UserService{
 		store: us,
-		mail:  m,
+		mail:  newMailQueue(m, outbox),
+		files: files,
 	}
 }
 
@@ -49,12 +55,7 @@ func (s *UserService) CreateUser(ctx context.Context, name, email, password stri
 		Verified:     false,
 	}
 
-	if err := s.store.InsertUser(ctx, user); err != nil {
-		return nil, err
-	}
-
 	otpString := generateOTP()
-	slog.Debug("OTP verificatio code", "code", otpString) //TODO: delete this line later
 	otpHash := hashString(otpString)
 
 	userAddr := mail.Address{Name: user.Name, Email: user.Email}
@@ -70,9 +71,20 @@ func (s *UserService) CreateUser(ctx context.Context, name, email, password stri
 		Scope:     VERIFICATION,
 	}
 
-	_ = s.store.InsertToken(ctx, &token)
+	err = s.store.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.store.InsertUser(ctx, user); err != nil {
+			return err
+		}
 
-	s.sendEmail([]mail.Address{userAddr}, "verify_email.html", data)
+		if err := s.store.InsertToken(ctx, &token); err != nil {
+			return err
+		}
+
+		return s.mail.send(ctx, []mail.Address{userAddr}, "verify_email.html", data)
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	return user, nil
 }
@@ -90,17 +102,22 @@ func (us *UserService) VerifyUser(ctx context.Context, code string, email string
 
 	user.Verified = true
 
-	err = us.store.UpdateUser(ctx, &user)
+	err = us.store.WithinTx(ctx, func(ctx context.Context) error {
+		err := us.store.UpdateUser(ctx, &user)
+		if err != nil {
+			return err
+		}
+
+		// Delete otp after successful verification
+		_ = us.store.DeleteToken(ctx, hash, VERIFICATION)
+
+		address := mail.Address{Name: user.Name, Email: user.Email}
+		return us.mail.send(ctx, []mail.Address{address}, "welcome_email.html", mail.Data{Address: address})
+	})
 	if err != nil {
 		return models.User{}, err
 	}
 
-	// Delete otp after successful verification
-	_ = us.store.DeleteToken(ctx, hash, VERIFICATION)
-
-	address := mail.Address{Name: user.Name, Email: user.Email}
-	us.sendEmail([]mail.Address{address}, "welcome_email.html", mail.Data{Address: address})
-
 	return user, nil
 }
 
@@ -115,8 +132,6 @@ func (us *UserService) ResendVerificationEmail(ctx context.Context, email string
 	otpString := generateOTP()
 	otpHash := hashString(otpString)
 
-	slog.Debug("OTP verificatio code", "code", otpString) //TODO: delete this line later
-
 	userAddr := mail.Address{Email: email, Name: user.Name}
 	data := mail.Data{
 		Address: userAddr,
@@ -130,17 +145,18 @@ func (us *UserService) ResendVerificationEmail(ctx context.Context, email string
 		Scope:     VERIFICATION,
 	}
 
-	err = us.store.InsertToken(ctx, &token)
-	if err != nil {
-		return err
-	}
-
-	us.sendEmail([]mail.Address{userAddr}, "verify_email.html", data)
+	return us.store.WithinTx(ctx, func(ctx context.Context) error {
+		err := us.store.InsertToken(ctx, &token)
+		if err != nil {
+			return err
+		}
 
-	return nil
+		return us.mail.send(ctx, []mail.Address{userAddr}, "verify_email.html", data)
+	})
 }
 
-func (us *UserService) NewSession(ctx context.Context, email string, password string) (*auth.UserSession, error) {
+// NewSession signs the user in, recording the device the session was created from.
+func (us *UserService) NewSession(ctx context.Context, email, password, userAgent, ipAddress string) (*auth.UserSession, error) {
 	user, err := us.store.GetUserByMail(ctx, email)
 	if err != nil {
 		return nil, err
@@ -159,20 +175,14 @@ func (us *UserService) NewSession(ctx context.Context, email string, password st
 		return nil, ErrFailedOperation
 	}
 
-	ttl := 15 * (24 * time.Hour)
-	refresh, err := auth.GenerateToken(user.Id, user.Email, ttl, auth.TokenTypeRefresh)
+	refresh, token, err := newRefreshToken(user)
 	if err != nil {
 		return nil, ErrFailedOperation
 	}
 
-	expiresAt := time.Now().Add(ttl)
-	tokenHash := hashString(refresh)
-	token := models.UserToken{
-		Hash:      tokenHash,
-		UserId:    user.Id,
-		ExpiresAt: expiresAt,
-		Scope:     AUTHENTICATION,
-	}
+	token.SessionId = uuid.New()
+	token.UserAgent = userAgent
+	token.IPAddress = ipAddress
 
 	err = us.store.InsertToken(ctx, &token)
 	if err != nil {
@@ -182,12 +192,34 @@ func (us *UserService) NewSession(ctx context.Context, email string, password st
 	session := &auth.UserSession{
 		User:         user,
 		RefreshToken: refresh,
-		ExpiresAt:    expiresAt,
+		ExpiresAt:    token.ExpiresAt,
 	}
 
 	return session, nil
 }
 
+// newRefreshToken generates a signed refresh token for the user along with
+// the record used to persist it.
+func newRefreshToken(user models.User) (string, models.UserToken, error) {
+	ttl := 15 * (24 * time.Hour)
+	refresh, err := auth.GenerateToken(user.Id, user.Email, ttl, auth.TokenTypeRefresh)
+	if err != nil {
+		return "", models.UserToken{}, err
+	}
+
+	token := models.UserToken{
+		Hash:      hashString(refresh),
+		UserId:    user.Id,
+		ExpiresAt: time.Now().Add(ttl),
+		Scope:     AUTHENTICATION,
+	}
+
+	return refresh, token, nil
+}
+
+// RefreshSession issues a new access token and rotates the refresh token.
+// Presenting a refresh token that has already been rotated is treated as a
+// sign of theft and revokes every session of the user.
 func (us *UserService) RefreshSession(ctx context.Context, refreshToken string) (*auth.UserAccess, error) {
 	claims, err := auth.ValidateToken(refreshToken, auth.TokenTypeRefresh)
 	if err != nil {
@@ -197,7 +229,7 @@ func (us *UserService) RefreshSession(ctx context.Context, refreshToken string)
 
 	hash := hashString(refreshToken)
 
-	user, err := us.store.GetUserForToken(ctx, hash, AUTHENTICATION, claims.Email)
+	current, err := us.store.GetToken(ctx, hash, AUTHENTICATION)
 	if err != nil {
 		if errors.Is(err, models.ErrNotFound) {
 			return nil, ErrInvalidToken
@@ -205,6 +237,43 @@ func (us *UserService) RefreshSession(ctx context.Context, refreshToken string)
 		return nil, err
 	}
 
+	if !current.RotatedAt.IsZero() {
+		us.revokeReusedToken(ctx, current)
+		return nil, ErrInvalidToken
+	}
+
+	user, err := us.store.GetUser(ctx, current.UserId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	if user.Email != claims.Email {
+		return nil, ErrInvalidToken
+	}
+
+	refresh, next, err := newRefreshToken(user)
+	if err != nil {
+		return nil, ErrFailedOperation
+	}
+
+	next.SessionId = current.SessionId
+	next.CreatedAt = current.CreatedAt
+	next.UserAgent = current.UserAgent
+	next.IPAddress = current.IPAddress
+
+	err = us.store.RotateToken(ctx, hash, &next)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			// another request rotated this token first
+			us.revokeReusedToken(ctx, current)
+			return nil, ErrInvalidToken
+		}
+		return nil, ErrFailedOperation
+	}
+
 	ttl := 2 * time.Hour // TODO: make time shorter
 	accessToken, err := auth.GenerateToken(user.Id, user.Email, ttl, auth.TokenTypeAccess)
 	if err != nil {
@@ -212,13 +281,156 @@ func (us *UserService) RefreshSession(ctx context.Context, refreshToken string)
 	}
 	// FIXME: obtain expiry time from GenerateToken function
 	useracc := &auth.UserAccess{
-		AccessToken: accessToken,
-		ExpiresAt:   time.Now().Add(ttl),
+		AccessToken:      accessToken,
+		ExpiresAt:        time.Now().Add(ttl),
+		RefreshToken:     refresh,
+		RefreshExpiresAt: next.ExpiresAt,
 	}
 
 	return useracc, nil
 }
 
+// revokeReusedToken revokes the token family of a refresh token that was
+// presented after it had already been rotated. Whoever reused it may have
+// opened other sessions with the stolen credentials, so every refresh token
+// of the user is revoked, not only those of the session.
+func (us *UserService) revokeReusedToken(ctx context.Context, token models.UserToken) {
+	slog.Warn("security event: refresh token reuse detected, revoking all sessions",
+		"event", "refresh_token_reuse",
+		"user_id", token.UserId,
+		"session_id", token.SessionId,
+		"rotated_at", token.RotatedAt,
+	)
+
+	err := us.store.DeleteTokensForUser(ctx, token.UserId, AUTHENTICATION)
+	if err != nil {
+		slog.Error("failed to revoke reused token family", "error", err, "user_id", token.UserId)
+	}
+}
+
+// Logout ends the session the refresh token belongs to.
+func (us *UserService) Logout(ctx context.Context, refreshToken string) error {
+	_, err := auth.ValidateToken(refreshToken, auth.TokenTypeRefresh)
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	token, err := us.store.GetToken(ctx, hashString(refreshToken), AUTHENTICATION)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return ErrInvalidToken
+		}
+		return ErrFailedOperation
+	}
+
+	if token.SessionId == uuid.Nil {
+		return us.store.DeleteToken(ctx, token.Hash, AUTHENTICATION)
+	}
+
+	return us.store.DeleteSession(ctx, token.UserId, token.SessionId)
+}
+
+// GetSessions lists the user's active sessions, newest first.
+func (us *UserService) GetSessions(ctx context.Context, userId uuid.UUID) ([]models.Session, error) {
+	return us.store.GetSessions(ctx, userId)
+}
+
+// RevokeSession signs the user out of a single session.
+func (us *UserService) RevokeSession(ctx context.Context, userId, sessionId uuid.UUID) error {
+	return us.store.DeleteSession(ctx, userId, sessionId)
+}
+
+// RevokeAllSessions signs the user out everywhere.
+func (us *UserService) RevokeAllSessions(ctx context.Context, userId uuid.UUID) error {
+	return us.store.DeleteTokensForUser(ctx, userId, AUTHENTICATION)
+}
+
+// RequestPasswordReset emails the user a one-time token for choosing a new
+// password. Unlike verification codes the token is long and random, so it
+// cannot be brute-forced. Any previously issued reset token is invalidated.
+func (us *UserService) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := us.store.GetUserByMail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	resetToken := generateResetToken()
+
+	userAddr := mail.Address{Name: user.Name, Email: user.Email}
+	data := mail.Data{
+		Address: userAddr,
+		Code:    resetToken,
+	}
+
+	token := models.UserToken{
+		Hash:      hashString(resetToken),
+		UserId:    user.Id,
+		ExpiresAt: time.Now().Add(15 * time.Minute),
+		Scope:     PASSWORD_RESET,
+	}
+
+	err = us.store.WithinTx(ctx, func(ctx context.Context) error {
+		err := us.store.DeleteTokensForUser(ctx, user.Id, PASSWORD_RESET)
+		if err != nil {
+			return err
+		}
+
+		err = us.store.InsertToken(ctx, &token)
+		if err != nil {
+			return err
+		}
+
+		return us.mail.send(ctx, []mail.Address{userAddr}, "reset_password.html", data)
+	})
+	if err != nil {
+		return ErrFailedOperation
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password for the user if code is a valid reset
+// token. The token is consumed and all of the user's sessions are revoked.
+func (us *UserService) ResetPassword(ctx context.Context, email, code, password string) error {
+	if len(password) < 8 || len(password) > 20 {
+		return ErrInvalidPassword
+	}
+
+	user, err := us.store.GetUserForToken(ctx, hashString(code), PASSWORD_RESET, email)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return ErrInvalidToken
+		}
+		return ErrFailedOperation
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		slog.Error("failed to hash password", "error", err)
+		return ErrFailedOperation
+	}
+
+	user.PasswordHash = hash
+	user.LastModifed = time.Now().UTC()
+
+	err = us.store.UpdateUser(ctx, &user)
+	if err != nil {
+		return ErrFailedOperation
+	}
+
+	err = us.store.DeleteTokensForUser(ctx, user.Id, PASSWORD_RESET)
+	if err != nil {
+		return ErrFailedOperation
+	}
+
+	err = us.store.DeleteTokensForUser(ctx, user.Id, AUTHENTICATION)
+	if err != nil {
+		return ErrFailedOperation
+	}
+
+	return nil
+}
+
 // UpdateUser updates an existing user's details
 func (us *UserService) UpdateUser(ctx context.Context, userData map[string]any) (*models.User, error) {
 	id, ok := userData["id"]
@@ -236,11 +448,6 @@ func (us *UserService) UpdateUser(ctx context.Context, userData map[string]any)
 		user.Name = name.(string)
 	}
 
-	profilePhoto, ok := userData["profilePhoto"]
-	if ok {
-		user.ProfilePhoto = profilePhoto.(string)
-	}
-
 	password, ok := userData["password"]
 	if ok {
 		if len(password.(string)) < 8 || len(password.(string)) > 20 {
@@ -286,3 +493,97 @@ func (s *UserService) FetchUser(ctx context.Context, id uuid.UUID) (*models.User
 func (s *UserService) DeleteUser(ctx context.Context, id string) error {
 	return s.store.DeleteUser(ctx, id)
 }
+
+// profilePhotoURL is the path a user's uploaded profile photo is served from.
+const profilePhotoURL = "/api/v1/users/%s/photo"
+
+// SetProfilePhoto stores the image read from r as the user's profile photo,
+// replacing any previous one.
+func (s *UserService) SetProfilePhoto(ctx context.Context, id uuid.UUID, r io.Reader) (*models.User, error) {
+	user, err := s.store.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType, r, err := sniff(r)
+	if err != nil {
+		return nil, ErrFailedOperation
+	}
+	if !allowedType(contentType, photoTypes) {
+		return nil, ErrFileType
+	}
+
+	previous := user.PhotoKey
+	user.PhotoKey = fmt.Sprintf("users/%s/%s", user.Id, uuid.New())
+
+	_, err = putFile(ctx, s.files, user.PhotoKey, r, MaxPhotoSize)
+	if err != nil {
+		return nil, err
+	}
+
+	user.ProfilePhoto = fmt.Sprintf(profilePhotoURL, user.Id)
+	user.LastModifed = time.Now().UTC()
+
+	err = s.store.UpdateUser(ctx, &user)
+	if err != nil {
+		deleteFile(ctx, s.files, user.PhotoKey)
+		return nil, err
+	}
+
+	if previous != "" {
+		deleteFile(ctx, s.files, previous)
+	}
+
+	return &user, nil
+}
+
+// RemoveProfilePhoto clears the user's profile photo.
+func (s *UserService) RemoveProfilePhoto(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	user, err := s.store.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := user.PhotoKey
+	user.PhotoKey = ""
+	user.ProfilePhoto = ""
+	user.LastModifed = time.Now().UTC()
+
+	err = s.store.UpdateUser(ctx, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	if previous != "" {
+		deleteFile(ctx, s.files, previous)
+	}
+
+	return &user, nil
+}
+
+// OpenProfilePhoto returns the content type of the user's profile photo and
+// a reader for it, which the caller must close.
+func (s *UserService) OpenProfilePhoto(ctx context.Context, id uuid.UUID) (string, io.ReadCloser, error) {
+	user, err := s.store.GetUser(ctx, id)
+	if err != nil {
+		return "", nil, err
+	} else if user.PhotoKey == "" {
+		return "", nil, models.ErrNotFound
+	}
+
+	f, err := openFile(ctx, s.files, user.PhotoKey)
+	if err != nil {
+		return "", nil, err
+	}
+
+	contentType, r, err := sniff(f)
+	if err != nil {
+		f.Close()
+		return "", nil, ErrFailedOperation
+	}
+
+	return contentType, struct {
+		io.Reader
+		io.Closer
+	}{r, f}, nil
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/primekobie/hazel/auth"
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rotatedTokenStore serves a single refresh token that has already been
+// rotated, and records whose tokens are revoked.
+type rotatedTokenStore struct {
+	models.UserStore
+	token   models.UserToken
+	revoked []uuid.UUID
+}
+
+func (f *rotatedTokenStore) GetToken(ctx context.Context, tokenHash, scope string) (models.UserToken, error) {
+	return f.token, nil
+}
+
+func (f *rotatedTokenStore) DeleteTokensForUser(ctx context.Context, userId uuid.UUID, scope string) error {
+	f.revoked = append(f.revoked, userId)
+	return nil
+}
+
+func TestRefreshSession_Reuse(t *testing.T) {
+	t.Setenv("TOKEN_SECRET", "secret")
+
+	userId := uuid.New()
+	refresh, err := auth.GenerateToken(userId, "ada@example.com", time.Hour, auth.TokenTypeRefresh)
+	require.NoError(t, err)
+
+	store := &rotatedTokenStore{token: models.UserToken{
+		Hash:      hashString(refresh),
+		UserId:    userId,
+		SessionId: uuid.New(),
+		Scope:     AUTHENTICATION,
+		RotatedAt: time.Now(),
+	}}
+	us := &UserService{store: store}
+
+	_, err = us.RefreshSession(context.Background(), refresh)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	assert.Equal(t, []uuid.UUID{userId}, store.revoked, "every session of the user is revoked")
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+)
+
+// taskSorts are the sort keys task listings accept.
+var taskSorts = []string{"", "rank", "created_at", "due", "priority"}
+
+// forUser resolves the parts of filter that depend on who runs it.
+func forUser(filter models.TaskFilter, userId uuid.UUID) models.TaskFilter {
+	if filter.Mine {
+		filter.Assignee = userId
+	}
+	return filter
+}
+
+// validateViewFilter checks the parts of a saved filter that would otherwise
+// only fail when the view is run.
+func validateViewFilter(filter models.TaskFilter) error {
+	if !slices.Contains(taskSorts, filter.Sort) || filter.Limit < 0 || filter.Limit > models.MaxPageSize {
+		return ErrInvalidViewFilter
+	}
+
+	for _, priority := range filter.Priority {
+		switch priority {
+		case models.PriorityLow, models.PriorityMedium, models.PriorityHigh:
+		default:
+			return ErrInvalidViewFilter
+		}
+	}
+
+	if filter.Mine && filter.Assignee != uuid.Nil {
+		return ErrInvalidViewFilter
+	}
+
+	return nil
+}
+
+// validateViewProject checks that a view's project belongs to its workspace.
+func (s *WorkspaceService) validateViewProject(ctx context.Context, workspaceId, projectId uuid.UUID) error {
+	project, err := s.store.GetProject(ctx, projectId)
+	if err != nil {
+		if err == models.ErrNotFound {
+			return ErrInvalidViewProject
+		}
+		return err
+	} else if project.Workspace.Id != workspaceId {
+		return ErrInvalidViewProject
+	}
+
+	return nil
+}
+
+// CreateView saves a view owned by view.OwnerId.
+func (s *WorkspaceService) CreateView(ctx context.Context, view *models.View) error {
+	view.Name = strings.TrimSpace(view.Name)
+	view.Filter.Cursor = ""
+
+	if !view.GroupBy.Valid() {
+		return ErrInvalidGrouping
+	}
+	if err := validateViewFilter(view.Filter); err != nil {
+		return err
+	}
+	if view.ProjectId != uuid.Nil {
+		if err := s.validateViewProject(ctx, view.WorkspaceId, view.ProjectId); err != nil {
+			return err
+		}
+	}
+
+	view.Id = uuid.New()
+	now := time.Now().UTC()
+	view.CreatedAt = now
+	view.LastModified = now
+
+	err := s.store.CreateView(ctx, view)
+	if err != nil {
+		if strings.Contains(err.Error(), "SQLSTATE 23505") {
+			return ErrDuplicateEntry
+		}
+		return ErrFailedOperation
+	}
+
+	return nil
+}
+
+// GetWorkspaceViews returns the user's own views of the workspace and those
+// shared with it.
+func (s *WorkspaceService) GetWorkspaceViews(ctx context.Context, workspaceId, userId uuid.UUID) ([]models.View, error) {
+	return s.store.GetWorkspaceViews(ctx, workspaceId, userId)
+}
+
+// GetView returns a view the user owns or that is shared, reporting other
+// users' private views as not found.
+func (s *WorkspaceService) GetView(ctx context.Context, viewId, userId uuid.UUID) (*models.View, error) {
+	view, err := s.store.GetView(ctx, viewId)
+	if err != nil {
+		return nil, err
+	} else if view.OwnerId != userId && !view.Shared {
+		return nil, models.ErrNotFound
+	}
+
+	return view, nil
+}
+
+// UpdateView changes the fields of a view that are not nil. Only the owner
+// of a view may change it. An empty projectId widens the view to every
+// project of the workspace.
+func (s *WorkspaceService) UpdateView(ctx context.Context, viewId, userId uuid.UUID, name *string, projectId *string, shared *bool, filter *models.TaskFilter, groupBy *models.ViewGrouping) (*models.View, error) {
+	view, err := s.GetView(ctx, viewId, userId)
+	if err != nil {
+		return nil, err
+	} else if view.OwnerId != userId {
+		return nil, ErrPermissionDenied
+	}
+
+	if name != nil {
+		view.Name = strings.TrimSpace(*name)
+	}
+	if projectId != nil {
+		id := uuid.Nil
+		if *projectId != "" {
+			id, err = uuid.Parse(*projectId)
+			if err != nil {
+				return nil, ErrInvalidViewProject
+			}
+		}
+		if id != uuid.Nil && id != view.ProjectId {
+			if err := s.validateViewProject(ctx, view.WorkspaceId, id); err != nil {
+				return nil, err
+			}
+		}
+		view.ProjectId = id
+	}
+	if shared != nil {
+		view.Shared = *shared
+	}
+	if filter != nil {
+		if err := validateViewFilter(*filter); err != nil {
+			return nil, err
+		}
+		view.Filter = *filter
+		view.Filter.Cursor = ""
+	}
+	if groupBy != nil {
+		if !groupBy.Valid() {
+			return nil, ErrInvalidGrouping
+		}
+		view.GroupBy = *groupBy
+	}
+	view.LastModified = time.Now().UTC()
+
+	err = s.store.UpdateView(ctx, view)
+	if err != nil {
+		if strings.Contains(err.Error(), "SQLSTATE 23505") {
+			return nil, ErrDuplicateEntry
+		}
+		return nil, err
+	}
+
+	return view, nil
+}
+
+// DeleteView removes a view. Views may be deleted by their owner, and shared
+// views also by a workspace admin.
+func (s *WorkspaceService) DeleteView(ctx context.Context, viewId, userId uuid.UUID, role models.Role) error {
+	view, err := s.GetView(ctx, viewId, userId)
+	if err != nil {
+		return err
+	} else if view.OwnerId != userId && !role.Includes(models.RoleAdmin) {
+		return ErrPermissionDenied
+	}
+
+	return s.store.DeleteView(ctx, view.Id)
+}
+
+// GetViewTasks runs a view for the user. The page size and cursor of opts
+// are applied to the view's saved filter; a zero limit keeps the saved one.
+func (s *WorkspaceService) GetViewTasks(ctx context.Context, viewId, userId uuid.UUID, opts models.ListOptions) (*models.Page[models.Task], error) {
+	view, err := s.GetView(ctx, viewId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := forUser(view.Filter, userId)
+	filter.Cursor = opts.Cursor
+	if opts.Limit > 0 {
+		filter.Limit = opts.Limit
+	}
+
+	var page *models.Page[models.Task]
+	if view.ProjectId != uuid.Nil {
+		page, err = s.store.GetTasksForProject(ctx, view.ProjectId, filter)
+	} else {
+		page, err = s.store.GetTasksForWorkspace(ctx, view.WorkspaceId, filter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.loadLabels(ctx, page.Items); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/primekobie/hazel/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateViewFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter models.TaskFilter
+		want   error
+	}{
+		{name: "empty", filter: models.TaskFilter{}},
+		{name: "mine overdue high", filter: models.TaskFilter{Mine: true, Overdue: true, Priority: []models.TaskPriority{models.PriorityHigh}}},
+		{name: "sorted", filter: models.TaskFilter{ListOptions: models.ListOptions{Sort: "due", Desc: true, Limit: 50}}},
+		{name: "unknown sort", filter: models.TaskFilter{ListOptions: models.ListOptions{Sort: "title"}}, want: ErrInvalidViewFilter},
+		{name: "page too large", filter: models.TaskFilter{ListOptions: models.ListOptions{Limit: models.MaxPageSize + 1}}, want: ErrInvalidViewFilter},
+		{name: "unknown priority", filter: models.TaskFilter{Priority: []models.TaskPriority{"urgent"}}, want: ErrInvalidViewFilter},
+		{name: "mine and assignee", filter: models.TaskFilter{Mine: true, Assignee: uuid.New()}, want: ErrInvalidViewFilter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, validateViewFilter(tt.filter), tt.want)
+		})
+	}
+}
+
+func TestForUser(t *testing.T) {
+	userId := uuid.New()
+
+	filter := forUser(models.TaskFilter{Mine: true}, userId)
+	assert.Equal(t, userId, filter.Assignee)
+
+	assignee := uuid.New()
+	filter = forUser(models.TaskFilter{Assignee: assignee}, userId)
+	assert.Equal(t, assignee, filter.Assignee)
+}
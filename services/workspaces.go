@@ -2,20 +2,28 @@ package services
 
 import (
 	"context"
-	"strings"
 	"time"
 
+	"github.com/primekobie/hazel/events"
+	"github.com/primekobie/hazel/mail"
 	"github.com/primekobie/hazel/models"
+	"github.com/primekobie/hazel/storage"
 	"github.com/google/uuid"
 )
 
 type WorkspaceService struct {
-	store models.WorkspaceStore
+	store  models.WorkspaceStore
+	mail   *mailQueue
+	events *events.Bus
+	files  storage.Storage
 }
 
-func NewWorkspaceService(store models.WorkspaceStore) *WorkspaceService {
+func NewWorkspaceService(store models.WorkspaceStore, m *mail.Mailer, outbox models.OutboxStore, bus *events.Bus, files storage.Storage) *WorkspaceService {
 	return &WorkspaceService{
-		store: store,
+		store:  store,
+		mail:   newMailQueue(m, outbox),
+		events: bus,
+		files:  files,
 	}
 }
 
@@ -24,13 +32,15 @@ func (s *WorkspaceService) NewWorkspace(ctx context.Context, ws *models.Workspac
 	createdAt := time.Now().UTC()
 	ws.CreatedAt = createdAt
 	ws.LastModified = createdAt
-	ws.User.Role = "owner"
+	ws.User.Role = string(models.RoleOwner)
 
 	err := s.store.Create(ctx, ws)
 	if err != nil {
 		return err
 	}
 
+	s.record(ctx, ws.Id, models.ResourceWorkspace, ws.Id, models.ActionCreated, diff(nil, workspaceFields(ws)))
+
 	return nil
 }
 
@@ -56,6 +66,7 @@ func (s *WorkspaceService) UpdateWorkspace(ctx context.Context, wsData map[strin
 	if err != nil {
 		return nil, err
 	}
+	before := workspaceFields(workspace)
 
 	name, ok := wsData["name"]
 	if ok {
@@ -74,30 +85,58 @@ func (s *WorkspaceService) UpdateWorkspace(ctx context.Context, wsData map[strin
 		return nil, err
 	}
 
+	if changes := diff(before, workspaceFields(workspace)); len(changes) > 0 {
+		s.record(ctx, workspace.Id, models.ResourceWorkspace, workspace.Id, models.ActionUpdated, changes)
+	}
+
 	return workspace, nil
 }
 
 func (s *WorkspaceService) DeleteWorkspace(ctx context.Context, id uuid.UUID) error {
-	return s.store.Delete(ctx, id)
-}
-
-func (s *WorkspaceService) AddWorkspaceMember(ctx context.Context, workspaceId, userId uuid.UUID, role string) error {
-	err := s.store.AddMembership(ctx, workspaceId, userId, role)
+	err := s.store.Delete(ctx, id)
 	if err != nil {
-		if strings.Contains(err.Error(), "SQLSTATE 23505") {
-			return ErrDuplicateEntry
-		}
-
-		return ErrFailedOperation
+		return err
 	}
 
+	// the activity log goes with the workspace, so the deletion is only announced to subscribers
+	s.publish(newActivity(ctx, id, models.ResourceWorkspace, id, models.ActionDeleted, nil))
+
 	return nil
 }
 
-func (s *WorkspaceService) GetWorkspaceMembers(ctx context.Context, id uuid.UUID) (any, error) {
-	return s.store.GetWorkspaceMembers(ctx, id)
+func (s *WorkspaceService) GetWorkspaceMembers(ctx context.Context, id uuid.UUID, filter models.MemberFilter) (*models.Page[models.User], error) {
+	return s.store.GetWorkspaceMembers(ctx, id, filter)
 }
 
-func (s *WorkspaceService) DeleteWorkspaceMember(ctx context.Context, workspaceId, userId uuid.UUID) error {
-	return s.store.DeleteMembership(ctx, workspaceId, userId)
+// DeleteWorkspaceMember removes memberId from a workspace on behalf of
+// userId, who has role in it. Members may leave a workspace themselves;
+// removing others requires admin rights, and removing an admin requires the
+// owner. The owner can neither leave nor be removed, so every workspace keeps
+// someone who can manage it.
+func (s *WorkspaceService) DeleteWorkspaceMember(ctx context.Context, workspaceId, memberId, userId uuid.UUID, role models.Role) error {
+	memberRole, err := s.store.GetMemberRole(ctx, workspaceId, memberId)
+	if err != nil {
+		return err
+	}
+
+	if memberRole == models.RoleOwner {
+		return ErrOwnerRemoval
+	} else if memberId != userId {
+		required := models.RoleAdmin
+		if memberRole == models.RoleAdmin {
+			required = models.RoleOwner
+		}
+		if !role.Includes(required) {
+			return ErrPermissionDenied
+		}
+	}
+
+	err = s.store.DeleteMembership(ctx, workspaceId, memberId)
+	if err != nil {
+		return err
+	}
+
+	s.record(ctx, workspaceId, models.ResourceMember, memberId, models.ActionRemoved, nil)
+
+	return nil
 }
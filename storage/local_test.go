@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocal_PutOpenDelete(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	n, err := store.Put(ctx, "tasks/1/a", strings.NewReader("first"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), n)
+
+	_, err = store.Put(ctx, "tasks/1/a", strings.NewReader("second"))
+	require.NoError(t, err)
+
+	r, err := store.Open(ctx, "tasks/1/a")
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	r.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+
+	require.NoError(t, store.Delete(ctx, "tasks/1/a"))
+	require.NoError(t, store.Delete(ctx, "tasks/1/a"))
+
+	_, err = store.Open(ctx, "tasks/1/a")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestLocal_InvalidKeys(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "/etc/passwd", "..", "../outside", "tasks/../../outside", "tasks//a", "tasks/./a"} {
+		_, err := store.Put(ctx, key, strings.NewReader("data"))
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+
+		_, err = store.Open(ctx, key)
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+	}
+}
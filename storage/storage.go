@@ -0,0 +1,28 @@
+// Package storage keeps the contents of uploaded files, such as task
+// attachments and profile photos, away from the database.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// Storage saves blobs under keys chosen by the caller. Keys are slash
+// separated paths such as "tasks/<task id>/<attachment id>".
+type Storage interface {
+	// Put writes the contents of r under key, replacing any blob already
+	// stored there, and returns the number of bytes written.
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	// Open returns a reader for the blob stored under key. The caller must
+	// close it.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob stored under key. Deleting a missing blob is
+	// not an error.
+	Delete(ctx context.Context, key string) error
+}